@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// batchResult is what solving one puzzle of a batch gave.
+type batchResult struct {
+	id      string // title of the puzzle, or its position in the file
+	clues   int
+	grid    *sudoku.Grid // as far as the solver got
+	solved  bool
+	hardest string
+	rating  sudoku.Rating
+	elapsed time.Duration
+	err     error // why the puzzle was not solved
+}
+
+// solveBatch solves every puzzle of the file at path, in a format
+// recognized by its extension, or lines on stdin for -. It writes one
+// result per puzzle to w, a CSV record when asCSV or a line otherwise,
+// and ends with a summary printed by logger. Puzzles are solved by
+// workers in parallel, each with a copy of solver sharing its
+// strategies, and their results written in order. The error
+// returned is the one of the first puzzle not solved, if any.
+func solveBatch(w io.Writer, path string, solver *sudoku.Solver, asCSV bool, workers int) error {
+	puzzles, invalid, err := readPuzzleFile(path)
+	if (err != nil) {
+		return err
+	}
+
+	var out = csv.NewWriter(w)
+	if (asCSV) {
+		out.Write([]string{"id", "clues", "solved", "hardest_technique", "ms", "score"})
+	}
+	var start = time.Now()
+	var results = make([]chan batchResult, len(puzzles))
+	for i := range results {
+		results[i] = make(chan batchResult, 1)
+	}
+	var jobs = make(chan int)
+	go func() {
+		for i := range puzzles {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+	for n := 0; n < workers; n++ {
+		go func() {
+			var own sudoku.Solver = *solver
+			for i := range jobs {
+				if (invalid[i] != nil) {
+					results[i] <- batchResult{id: strconv.Itoa(i + 1), err: invalid[i]}
+					continue
+				}
+				results[i] <- solveOne(&own, puzzles[i], i)
+			}
+		}()
+	}
+
+	var solved int = 0
+	var rated int = 0
+	var totalScore float64 = 0
+	var failure error = nil
+	for i := range puzzles {
+		var result = <-results[i]
+		if (result.solved) {
+			solved++
+		} else if (failure == nil) {
+			failure = fmt.Errorf("puzzle %s: %w", result.id, result.err)
+		}
+		if (result.rating.Level != "") {
+			rated++
+			totalScore += result.rating.Score
+		}
+
+		var ms = strconv.FormatFloat(result.elapsed.Seconds()*1000, 'f', 3, 64)
+		var score = ""
+		if (result.rating.Level != "") {
+			score = strconv.FormatFloat(result.rating.Score, 'f', 1, 64)
+		}
+		if (result.grid == nil) {
+			logAt(levelSummary, "puzzle %s: %v", result.id, result.err)
+		}
+		if (asCSV) {
+			out.Write([]string{result.id, strconv.Itoa(result.clues), strconv.FormatBool(result.solved), result.hardest, ms, score})
+			continue
+		}
+		var status = "unsolved"
+		var grid = "-"
+		switch {
+		case result.grid == nil:
+			status = "invalid"
+		case result.solved:
+			status = "solved"
+		}
+		if (result.grid != nil) {
+			grid = result.grid.String()
+		}
+		fmt.Fprintf(w, "%s %s %s %s %s %sms\n", result.id, grid, status, orDash(score), orDash(result.hardest), ms)
+	}
+	out.Flush()
+	if (out.Error() != nil) {
+		return out.Error()
+	}
+
+	var average = "none"
+	if (rated > 0) {
+		average = strconv.FormatFloat(totalScore/float64(rated), 'f', 1, 64)
+	}
+	logAt(levelSummary, "%d puzzles, %d solved, %d not solved, average difficulty %s, in %v.",
+		len(puzzles), solved, len(puzzles)-solved, average, time.Since(start).Round(time.Millisecond))
+	return failure
+}
+
+// readPuzzleFile reads the puzzles of the file at path, in a format
+// recognized by its extension, or lines on stdin for -. Lines which are
+// not puzzles do not stop the reading: they are kept as empty puzzles,
+// with the error telling why in invalid, which holds one error, or nil,
+// per puzzle.
+func readPuzzleFile(path string) (puzzles []sudoku.Puzzle, invalid []error, err error) {
+	var in io.Reader = os.Stdin
+	var format = "line"
+	if (path != "-") {
+		file, err := os.Open(path)
+		if (err != nil) {
+			return nil, nil, err
+		}
+		defer file.Close()
+		in = file
+		format = sudoku.FormatFromPath(path)
+	}
+	if (format == "line") {
+		puzzles, invalid, err = readPuzzleLines(in)
+	} else {
+		puzzles, err = sudoku.ReadPuzzles(in, format)
+		invalid = make([]error, len(puzzles))
+	}
+	if (err != nil) {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return puzzles, invalid, nil
+}
+
+// readPuzzleLines reads one puzzle per line, skipping blank lines and
+// comments starting with # as the line format does, see readPuzzleFile.
+func readPuzzleLines(r io.Reader) ([]sudoku.Puzzle, []error, error) {
+	var puzzles []sudoku.Puzzle
+	var invalid []error
+	var scanner = bufio.NewScanner(r)
+	var lineNum int = 0
+	for scanner.Scan() {
+		lineNum++
+		var line = strings.TrimSpace(scanner.Text())
+		if (line == "" || strings.HasPrefix(line, "#")) {
+			continue
+		}
+
+		cells, err := sudoku.ParseCells(line)
+		if (err != nil) {
+			err = fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		puzzles = append(puzzles, sudoku.Puzzle{Cells: cells})
+		invalid = append(invalid, err)
+	}
+	return puzzles, invalid, scanner.Err()
+}
+
+// solveOne solves puzzle p, the i-th of a batch, and rates it from the
+// same solve, see RateReport.
+func solveOne(solver *sudoku.Solver, p sudoku.Puzzle, i int) batchResult {
+	var result = batchResult{id: p.Title}
+	if (result.id == "") {
+		result.id = strconv.Itoa(i + 1)
+	}
+
+	var g = sudoku.NewGrid(p.Cells)
+	result.clues = 81 - g.CountEmptyCells()
+	g.ListOptions()
+	if (p.Candidates != nil) {
+		_, err := g.NarrowOptions(*p.Candidates)
+		if (err != nil) {
+			result.grid, result.err = g, err
+			return result
+		}
+	}
+	var ctx = context.Background()
+	var cancel context.CancelFunc = func() {}
+	if (*timeout > 0) {
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+	}
+	report, err := solver.SolveContext(ctx, g)
+	cancel()
+
+	result.grid = g
+	result.solved = report.Solved
+	result.hardest = solver.Hardest(report)
+	result.rating = sudoku.RateReport(report, err)
+	result.err = err
+	result.elapsed = report.Elapsed
+	return result
+}
+
+// orDash returns str, or - when it is empty, for fields separated by
+// spaces.
+func orDash(str string) string {
+	if (str == "") {
+		return "-"
+	}
+	return str
+}
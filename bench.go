@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// maxListed is the number of puzzles not solved bench lists.
+const maxListed = 20
+
+// runBench implements the bench subcommand:
+//   sudoksolv bench [--backend B] [--logic-only] FILE
+// It solves every puzzle of FILE and prints how long it took: the
+// minimum, median, percentiles and maximum times, the puzzles solved
+// per second, and the puzzles not solved, counting those whose solution
+// disagrees with the one FILE gives, see Puzzle.CheckSolution. The
+// error returned is the one of the first puzzle not solved, if any.
+func runBench(args []string) error {
+	var flags = flag.NewFlagSet("bench", flag.ContinueOnError)
+	var backend = flags.String("backend", "logic", "how to solve: logic (human techniques), dlx (dancing links search) or sat (kissat SAT solver)")
+	var logicOnly = flags.Bool("logic-only", false, "give up when the techniques are stuck, instead of backtracking")
+	var timeout = flags.Duration("timeout", 0, "give up each puzzle after this duration, counting it as failed (default no limit)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sudoksolv bench [--backend B] [--logic-only] FILE")
+		fmt.Fprintln(flags.Output(), "Solves every puzzle of FILE, in a format recognized by its extension or - for lines on stdin, and prints timing statistics.")
+		flags.PrintDefaults()
+	}
+	err := parseFlags(flags, args)
+	if (err != nil) {
+		return err
+	}
+	if (flags.NArg() != 1) {
+		flags.Usage()
+		return errUsage
+	}
+
+	var solver = sudoku.NewSolver()
+	solver.Backtrack = !*logicOnly
+	err = setBackend(solver, *backend)
+	if (err != nil) {
+		return err
+	}
+
+	var path = flags.Arg(0)
+	puzzles, invalid, err := readPuzzleFile(path)
+	if (err != nil) {
+		return err
+	}
+	// the times of a file which is not all puzzles would mislead
+	for _, err := range invalid {
+		if (err != nil) {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if (len(puzzles) == 0) {
+		return fmt.Errorf("%s: no puzzle to solve", path)
+	}
+
+	var times []time.Duration
+	var failed []int
+	var failure error = nil
+	var start = time.Now()
+	for i, p := range puzzles {
+		var g = sudoku.NewGrid(p.Cells)
+		var puzzleStart = time.Now()
+		g.ListOptions()
+		var err error = nil
+		if (p.Candidates != nil) {
+			_, err = g.NarrowOptions(*p.Candidates)
+		}
+
+		var ctx = context.Background()
+		var cancel context.CancelFunc = func() {}
+		if (*timeout > 0) {
+			ctx, cancel = context.WithTimeout(ctx, *timeout)
+		}
+		if (err == nil) {
+			_, err = solver.SolveContext(ctx, g)
+		}
+		cancel()
+		if (err == nil) {
+			err = p.CheckSolution(g.Cells())
+		}
+		times = append(times, time.Since(puzzleStart))
+		if (err != nil) {
+			failed = append(failed, i+1)
+			if (failure == nil) {
+				failure = fmt.Errorf("puzzle %d: %w", i+1, err)
+			}
+		}
+	}
+	var elapsed = time.Since(start)
+
+	slices.Sort(times)
+	fmt.Printf("%d puzzles in %v, %.1f puzzles per second.\n", len(puzzles), elapsed.Round(time.Millisecond), float64(len(puzzles))/elapsed.Seconds())
+	fmt.Printf("min %v, median %v, p90 %v, p99 %v, max %v\n", times[0], percentile(times, 0.5), percentile(times, 0.9), percentile(times, 0.99), times[len(times)-1])
+	if (len(failed) > maxListed) {
+		fmt.Printf("%d not solved: puzzles %v and more\n", len(failed), failed[:maxListed])
+	} else if (len(failed) > 0) {
+		fmt.Printf("%d not solved: puzzles %v\n", len(failed), failed)
+	} else {
+		fmt.Println("All solved.")
+	}
+	return failure
+}
+
+// percentile returns the time which a share q of the sorted times do
+// not exceed, by the nearest rank.
+func percentile(times []time.Duration, q float64) time.Duration {
+	var rank = int(math.Ceil(q * float64(len(times))))
+	return times[max(rank-1, 0)]
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// runBook implements the book subcommand:
+//   sudoksolv book [--pages N] [--per-page N] [--difficulty D,...] OUT.pdf
+// It generates puzzles and writes them as a printable PDF booklet,
+// with their solutions at the end.
+func runBook(args []string) error {
+	var flags = flag.NewFlagSet("book", flag.ContinueOnError)
+	var pages = flags.Int("pages", 10, "pages of puzzles, not counting the solutions")
+	var perPage = flags.Int("per-page", 4, "puzzles on each page, 1, 2, 4 or 6")
+	var difficulties = flags.String("difficulty", "easy,medium,hard", "comma-separated difficulties of the puzzles, from the first pages to the last, see generate")
+	var solutions = flags.Bool("solutions", true, "add pages with the solutions at the end")
+	var workers = flags.Int("workers", runtime.GOMAXPROCS(0), "puzzles generated in parallel")
+	var seed = seedFlag(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sudoksolv book [--pages N] [--per-page N] [--difficulty D,...] OUT.pdf")
+		fmt.Fprintln(flags.Output(), "Writes a PDF booklet of new puzzles, with their solutions at the end.")
+		flags.PrintDefaults()
+	}
+	err := parseFlags(flags, args)
+	if (err != nil) {
+		return err
+	}
+	if (flags.NArg() != 1) {
+		flags.Usage()
+		return errUsage
+	}
+	if (*pages < 1 || *workers < 1) {
+		return fmt.Errorf("--pages and --workers must be at least 1")
+	}
+	if (!slices.Contains([]int{1, 2, 4, 6}, *perPage)) {
+		return fmt.Errorf("cannot lay out %d puzzles per page, use 1, 2, 4 or 6", *perPage)
+	}
+
+	// the puzzles are shared between the difficulties, in order, the
+	// first ones getting one more when they cannot be evenly
+	var levels = strings.Split(*difficulties, ",")
+	var count = *pages * *perPage
+	if (len(levels) > count) {
+		return fmt.Errorf("%d difficulties do not fit in %d puzzles", len(levels), count)
+	}
+	var groups []sudoku.GenerateOptions
+	for _, difficulty := range levels {
+		var opts sudoku.GenerateOptions
+		err := parseDifficulty(strings.TrimSpace(difficulty), &opts)
+		if (err != nil) {
+			return err
+		}
+		groups = append(groups, opts)
+	}
+
+	var r = newRand(seed)
+	var puzzles []sudoku.Puzzle
+	for i, opts := range groups {
+		opts.Rand = r
+		var size = count / len(groups)
+		if (i < count%len(groups)) {
+			size++
+		}
+		group, _, err := generatePuzzles(opts, size, *workers)
+		if (err != nil) {
+			return err
+		}
+		fmt.Fprintln(os.Stderr)
+		for _, p := range group {
+			p.Title = p.Difficulty
+			puzzles = append(puzzles, p)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%d puzzles, seed %d.\n", len(puzzles), *seed)
+
+	var write = func(w io.Writer) error {
+		return sudoku.WritePDF(w, puzzles, sudoku.PDFOptions{PerPage: *perPage, Solutions: *solutions})
+	}
+	var path = flags.Arg(0)
+	if (path == "-") {
+		return write(os.Stdout)
+	}
+	return writeFile(path, write)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// version of sudoksolv, reported in stuck-state bundles.
+const version = "0.1.0"
+
+// stuckBundle is everything needed to reproduce a run where the solver
+// gave up: the original puzzle, the grid it reached with its givens
+// apart, the candidates of every empty cell and the flags it ran with.
+type stuckBundle struct {
+	Version    string      `json:"version"`
+	Revision   string      `json:"revision,omitempty"`
+	GoVersion  string      `json:"go_version"`
+	Platform   string      `json:"platform"`
+	Flags      []string    `json:"flags"`
+	Puzzle     string      `json:"puzzle"`
+	Grid       string      `json:"grid"`
+	Givens     string      `json:"givens"`
+	Candidates [9][9][]int `json:"candidates"`
+}
+
+// vcsRevision returns the commit the binary was built from, if known.
+func vcsRevision() string {
+	info, ok := debug.ReadBuildInfo()
+	if (!ok) {
+		return ""
+	}
+	for _, setting := range info.Settings {
+		if (setting.Key == "vcs.revision") {
+			return setting.Value
+		}
+	}
+	return ""
+}
+
+// writeBundle saves the state of g, together with the original puzzle,
+// to the given file so it can be attached to a bug report.
+func writeBundle(path string, puzzle string, g *sudoku.Grid) error {
+	var givens [9][9]int
+	var cells = g.Cells()
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (g.IsGiven(row, col)) {
+				givens[row][col] = cells[row][col]
+			}
+		}
+	}
+
+	var bundle = stuckBundle{
+		Version:    version,
+		Revision:   vcsRevision(),
+		GoVersion:  runtime.Version(),
+		Platform:   runtime.GOOS + "/" + runtime.GOARCH,
+		Flags:      os.Args[1:],
+		Puzzle:     puzzle,
+		Grid:       g.String(),
+		Givens:     sudoku.CellsString(givens, '0'),
+		Candidates: g.Options(),
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if (err != nil) {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// loadBundle restores a grid from a bundle written by writeBundle, and
+// returns it with the original puzzle. The cells placed since are told
+// apart from the givens, except in bundles written without them.
+func loadBundle(path string) (*sudoku.Grid, string, error) {
+	data, err := os.ReadFile(path)
+	if (err != nil) {
+		return nil, "", err
+	}
+
+	var bundle stuckBundle
+	err = json.Unmarshal(data, &bundle)
+	if (err != nil) {
+		return nil, "", fmt.Errorf("%s: %w", path, err)
+	}
+
+	cells, err := sudoku.ParseCells(bundle.Grid)
+	if (err != nil) {
+		return nil, "", fmt.Errorf("%s: %w", path, err)
+	}
+	var givens = cells
+	if (bundle.Givens != "") {
+		givens, err = sudoku.ParseCells(bundle.Givens)
+		if (err != nil) {
+			return nil, "", fmt.Errorf("%s: givens: %w", path, err)
+		}
+	}
+	var g = sudoku.NewGrid(givens)
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (givens[row][col] != 0 && givens[row][col] != cells[row][col]) {
+				return nil, "", fmt.Errorf("%s: the grid changes the given of %v", path, sudoku.Coord{Row: row, Col: col})
+			}
+			if (givens[row][col] == 0 && cells[row][col] != 0) {
+				g.SetCell(row, col, cells[row][col])
+			}
+		}
+	}
+	err = g.SetOptions(bundle.Candidates)
+	if (err != nil) {
+		return nil, "", fmt.Errorf("%s: %w", path, err)
+	}
+	return g, bundle.Puzzle, nil
+}
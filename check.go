@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// runCheck implements the check subcommand:
+//   sudoksolv check PUZZLE [GRID]
+// It tells whether GRID, the puzzle as far as a player filled it, is
+// consistent and matches the unique solution of PUZZLE, listing the
+// wrong cells but not their values. Without GRID, PUZZLE is checked on
+// its own. It returns an error, exiting with 1, when there is a mistake.
+func runCheck(args []string) error {
+	var flags = flag.NewFlagSet("check", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sudoksolv check PUZZLE [GRID]")
+		fmt.Fprintln(flags.Output(), "Checks GRID, filled from PUZZLE, against its solution without revealing it. Without GRID, checks that PUZZLE repeats no value and has a single solution.")
+		flags.PrintDefaults()
+	}
+	err := parseFlags(flags, args)
+	if (err != nil) {
+		return err
+	}
+	if (flags.NArg() < 1 || flags.NArg() > 2) {
+		flags.Usage()
+		return errUsage
+	}
+
+	puzzle, err := sudoku.Parse(flags.Arg(0))
+	if (err != nil) {
+		return err
+	}
+	var entries = puzzle
+	if (flags.NArg() == 2) {
+		entries, err = sudoku.Parse(flags.Arg(1))
+		if (err != nil) {
+			return err
+		}
+	}
+	err = puzzle.Validate()
+	if (err != nil) {
+		return err
+	}
+
+	result, err := puzzle.Check(entries)
+	var notUnique *sudoku.NotUniqueError
+	if (errors.As(err, &notUnique)) {
+		return fmt.Errorf("%w, so cells cannot be checked", err)
+	}
+	if (err != nil) {
+		return err
+	}
+
+	// conflicts are mistakes of the player, not invalid clues
+	var conflicts []error
+	joined, ok := result.Conflicts.(interface{ Unwrap() []error })
+	if (ok) {
+		conflicts = joined.Unwrap()
+	} else if (result.Conflicts != nil) {
+		conflicts = []error{result.Conflicts}
+	}
+	for _, err := range conflicts {
+		var conflict *sudoku.ClueError
+		if (!errors.As(err, &conflict)) {
+			fmt.Println(err)
+			continue
+		}
+		var cells []string
+		for _, cell := range conflict.Cells {
+			cells = append(cells, cell.String())
+		}
+		fmt.Printf("%d is repeated in %s (%s).\n", conflict.Value, conflict.House, strings.Join(cells, ", "))
+	}
+	switch {
+	case result.Filled == 0:
+		fmt.Printf("The puzzle is valid, with a single solution and %d cells to fill.\n", result.Empty)
+	case !result.Correct():
+		var cells []string
+		for _, cell := range result.Wrong {
+			cells = append(cells, cell.String())
+		}
+		fmt.Printf("%d cells filled, %d wrong: %s.\n", result.Filled, len(result.Wrong), strings.Join(cells, ", "))
+	case result.Empty == 0:
+		fmt.Println("Solved, every cell is correct.")
+	default:
+		fmt.Printf("%d cells filled, all correct, %d left.\n", result.Filled, result.Empty)
+	}
+	if (!result.Correct()) {
+		return fmt.Errorf("%d wrong cells", len(result.Wrong))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// clipboardCommands returns the commands reading from and writing to the
+// system clipboard on the current platform.
+func clipboardCommands() (pasteCmd []string, copyCmd []string, err error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"pbpaste"}, []string{"pbcopy"}, nil
+	case "windows":
+		return []string{"powershell.exe", "-NoProfile", "-Command", "Get-Clipboard"}, []string{"clip.exe"}, nil
+	}
+
+	if (os.Getenv("WAYLAND_DISPLAY") != "") {
+		_, err := exec.LookPath("wl-paste")
+		if (err == nil) {
+			return []string{"wl-paste", "--no-newline"}, []string{"wl-copy"}, nil
+		}
+	}
+	_, err = exec.LookPath("xclip")
+	if (err == nil) {
+		return []string{"xclip", "-selection", "clipboard", "-out"}, []string{"xclip", "-selection", "clipboard", "-in"}, nil
+	}
+	_, err = exec.LookPath("xsel")
+	if (err == nil) {
+		return []string{"xsel", "--clipboard", "--output"}, []string{"xsel", "--clipboard", "--input"}, nil
+	}
+	return nil, nil, errors.New("no clipboard tool found, install wl-clipboard, xclip or xsel")
+}
+
+// readClipboard returns the text content of the system clipboard.
+func readClipboard() (string, error) {
+	pasteCmd, _, err := clipboardCommands()
+	if (err != nil) {
+		return "", err
+	}
+
+	out, err := exec.Command(pasteCmd[0], pasteCmd[1:]...).Output()
+	if (err != nil) {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// writeClipboard replaces the content of the system clipboard with text.
+func writeClipboard(text string) error {
+	_, copyCmd, err := clipboardCommands()
+	if (err != nil) {
+		return err
+	}
+
+	var cmd = exec.Command(copyCmd[0], copyCmd[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
+
+// puzzleFromClipboard reads a puzzle from the clipboard. Whitespace is
+// ignored, so puzzles copied as nine lines work as well as one-liners.
+func puzzleFromClipboard() (string, error) {
+	text, err := readClipboard()
+	if (err != nil) {
+		return "", err
+	}
+
+	cells, err := sudoku.ParseCells(strings.Join(strings.Fields(text), ""))
+	if (err != nil) {
+		return "", err
+	}
+	return sudoku.CellsString(cells, '0'), nil
+}
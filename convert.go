@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// runConvert implements the convert subcommand:
+//   sudoksolv convert [--from FORMAT] [--to FORMAT] IN [OUT]
+// It converts puzzles between formats without solving them. Formats
+// default to the file extensions; - or a missing OUT means stdin/stdout.
+func runConvert(args []string) error {
+	var flags = flag.NewFlagSet("convert", flag.ContinueOnError)
+	var from = flags.String("from", "", "input format: "+strings.Join(sudoku.Formats, ", "))
+	var to = flags.String("to", "", "output format: "+strings.Join(sudoku.Formats, ", "))
+	var perPage = flags.Int("per-page", 2, "pdf: puzzles on each page, 1, 2, 4 or 6")
+	var solutions = flags.Bool("solutions", false, "pdf: add pages with the solutions at the end")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sudoksolv convert [--from FORMAT] [--to FORMAT] IN [OUT]")
+		flags.PrintDefaults()
+	}
+	err := parseFlags(flags, args)
+	if (err != nil) {
+		return err
+	}
+
+	if (flags.NArg() < 1 || flags.NArg() > 2) {
+		flags.Usage()
+		return errUsage
+	}
+	var inPath = flags.Arg(0)
+	var outPath = flags.Arg(1)
+
+	if (*from == "") {
+		*from = sudoku.FormatFromPath(inPath)
+	}
+	if (*to == "") {
+		*to = sudoku.FormatFromPath(outPath)
+	}
+
+	var in io.Reader = os.Stdin
+	if (inPath != "-") {
+		file, err := os.Open(inPath)
+		if (err != nil) {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	puzzles, err := sudoku.ReadPuzzles(in, *from)
+	if (err != nil) {
+		return fmt.Errorf("%s: %w", inPath, err)
+	}
+
+	var write = func(w io.Writer) error {
+		if (*to == "pdf") {
+			return sudoku.WritePDF(w, puzzles, sudoku.PDFOptions{PerPage: *perPage, Solutions: *solutions})
+		}
+		return sudoku.WritePuzzles(w, *to, puzzles)
+	}
+
+	if (outPath == "" || outPath == "-") {
+		return write(os.Stdout)
+	}
+
+	out, err := os.Create(outPath)
+	if (err != nil) {
+		return err
+	}
+	err = write(out)
+	if (err != nil) {
+		out.Close()
+		return fmt.Errorf("%s: %w", outPath, err)
+	}
+	return out.Close()
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// runDaily implements the daily subcommand:
+//   sudoksolv daily [--date YYYY-MM-DD] [--namespace NAME] [--difficulty D]
+// It prints the puzzle of the day, the same for everyone running it on
+// the same day, in UTC, with the same namespace and difficulty.
+func runDaily(args []string) error {
+	var flags = flag.NewFlagSet("daily", flag.ContinueOnError)
+	var dateFlag = flags.String("date", "", "day of the puzzle, as YYYY-MM-DD (default today, in UTC)")
+	var namespace = flags.String("namespace", "", "name making a puzzle of the day of its own, such as the name of a club")
+	var difficulty = flags.String("difficulty", "", "level (easy, medium, hard or diabolical) or score range (such as 3-4.5) of the puzzle, see the rate command")
+	var format = flags.String("format", "line", "output format: line (81 digits) or json (puzzle, solution, difficulty, date and seed)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sudoksolv daily [--date YYYY-MM-DD] [--namespace NAME] [--difficulty D]")
+		fmt.Fprintln(flags.Output(), "Prints the puzzle of the day, the same for everyone.")
+		flags.PrintDefaults()
+	}
+	err := parseFlags(flags, args)
+	if (err != nil) {
+		return err
+	}
+	if (flags.NArg() != 0) {
+		flags.Usage()
+		return errUsage
+	}
+
+	var date = time.Now().UTC()
+	if (*dateFlag != "") {
+		parsed, err := time.Parse(time.DateOnly, *dateFlag)
+		if (err != nil) {
+			return fmt.Errorf("invalid date %q, use YYYY-MM-DD", *dateFlag)
+		}
+		date = parsed
+	}
+	if (*format != "line" && *format != "json") {
+		return fmt.Errorf("unknown format %q, use line or json", *format)
+	}
+
+	var seed = sudoku.DailySeed(date, *namespace)
+	var opts = sudoku.GenerateOptions{Rand: rand.New(rand.NewSource(seed))}
+	err = parseDifficulty(*difficulty, &opts)
+	if (err != nil) {
+		return err
+	}
+	g, err := sudoku.Generate(opts)
+	if (err != nil) {
+		return err
+	}
+
+	var rating = sudoku.Rate(*g)
+	var title = "Puzzle of " + date.Format(time.DateOnly)
+	if (*namespace != "") {
+		title += " for " + *namespace
+	}
+	fmt.Fprintf(os.Stderr, "%s, %d clues, %s (%.1f).\n", title, 81-g.CountEmptyCells(), rating.Level, rating.Score)
+
+	var puzzle = generatedPuzzle(g, rating, seed)
+	puzzle.Title = title
+	puzzle.Source = "sudoksolv daily"
+	puzzle.Date = date.Format(time.DateOnly)
+	return sudoku.WritePuzzles(os.Stdout, *format, []sudoku.Puzzle{puzzle})
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// runGenerate implements the generate subcommand:
+//   sudoksolv generate [--symmetry S] [--difficulty D] [--seed N] [--count N] [--output FILE]
+// It prints new random puzzles with a single solution.
+func runGenerate(args []string) error {
+	var flags = flag.NewFlagSet("generate", flag.ContinueOnError)
+	var symmetryName = flags.String("symmetry", "none", "pattern the clues keep: "+symmetryNames())
+	var difficulty = flags.String("difficulty", "", "level (easy, medium, hard or diabolical) or score range (such as 3-4.5) of the puzzle, see the rate command")
+	var technique = flags.String("technique", "", "hardest technique the puzzle needs: "+strings.Join(techniqueNames(), ", "))
+	var minClues = flags.Int("min-clues", 0, "fewest clues of the puzzle, some of them may then not be needed")
+	var maxClues = flags.Int("max-clues", 0, "most clues of the puzzle")
+	var minimal = flags.Bool("minimal", false, "remove the clues left by --symmetry which are not needed, breaking it")
+	var tries = flags.Int("tries", sudoku.DefaultGenerateTries, "puzzles to try for --difficulty, --technique or --max-clues before giving up")
+	var seed = seedFlag(flags)
+	var count = flags.Int("count", 1, "number of distinct puzzles to generate")
+	var workers = flags.Int("workers", runtime.GOMAXPROCS(0), "puzzles generated in parallel for --count")
+	var output = flags.String("output", "", "file to write the puzzles to, instead of stdout")
+	var format = flags.String("format", "", "output format: "+strings.Join(sudoku.Formats, ", ")+" (default from the --output extension, or line)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sudoksolv generate [--symmetry S] [--difficulty D] [--seed N] [--count N] [--output FILE]")
+		fmt.Fprintln(flags.Output(), "Prints random puzzles with a single solution.")
+		flags.PrintDefaults()
+	}
+	err := parseFlags(flags, args)
+	if (err != nil) {
+		return err
+	}
+	if (flags.NArg() != 0) {
+		flags.Usage()
+		return errUsage
+	}
+
+	symmetry, err := sudoku.ParseSymmetry(*symmetryName)
+	if (err != nil) {
+		return fmt.Errorf("%v, use %s", err, symmetryNames())
+	}
+	if (*format == "") {
+		*format = sudoku.FormatFromPath(*output)
+	}
+	if (!slices.Contains(sudoku.Formats, *format)) {
+		return fmt.Errorf("unknown format %q, use %s", *format, strings.Join(sudoku.Formats, ", "))
+	}
+	if (*count < 1 || *workers < 1) {
+		return fmt.Errorf("--count and --workers must be at least 1")
+	}
+	var opts = sudoku.GenerateOptions{Symmetry: symmetry, Minimal: *minimal, Technique: *technique, MinClues: *minClues, MaxClues: *maxClues, Tries: *tries}
+	err = parseDifficulty(*difficulty, &opts)
+	if (err != nil) {
+		return err
+	}
+
+	opts.Rand = newRand(seed)
+	var puzzles []sudoku.Puzzle
+	if (*count == 1) {
+		g, err := sudoku.Generate(opts)
+		if (err != nil) {
+			return err
+		}
+		var rating = sudoku.Rate(*g)
+		var clues = fmt.Sprintf("%d clues", 81-g.CountEmptyCells())
+		if (g.IsMinimal()) {
+			clues += ", all needed"
+		}
+		fmt.Fprintf(os.Stderr, "%s, %s (%.1f), seed %d.\n", clues, rating.Level, rating.Score, *seed)
+		puzzles = append(puzzles, generatedPuzzle(g, rating, *seed))
+	} else {
+		var duplicates int
+		puzzles, duplicates, err = generatePuzzles(opts, *count, *workers)
+		if (err != nil) {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "\r%d puzzles, %d duplicates skipped, seed %d.\n", *count, duplicates, *seed)
+	}
+
+	if (*output == "" || *output == "-") {
+		return sudoku.WritePuzzles(os.Stdout, *format, puzzles)
+	}
+	out, err := os.Create(*output)
+	if (err != nil) {
+		return err
+	}
+	err = sudoku.WritePuzzles(out, *format, puzzles)
+	if (err != nil) {
+		out.Close()
+		return fmt.Errorf("%s: %w", *output, err)
+	}
+	return out.Close()
+}
+
+// techniqueNames returns the techniques generate --technique accepts,
+// those Rate uses.
+func techniqueNames() []string {
+	var names []string
+	for _, strategy := range sudoku.DefaultStrategies() {
+		names = append(names, strategy.Name())
+	}
+	return names
+}
+
+// generatedPuzzle returns g with its solution, rating and seed, for
+// the formats recording them.
+func generatedPuzzle(g *sudoku.Grid, rating sudoku.Rating, seed int64) sudoku.Puzzle {
+	return sudoku.Puzzle{
+		Cells:      g.Cells(),
+		Solution:   g.AllSolutions(1)[0].Cells(),
+		Difficulty: fmt.Sprintf("%s (%.1f)", rating.Level, rating.Score),
+		Source:     "sudoksolv generate",
+		Seed:       &seed,
+	}
+}
+
+// generated is a puzzle made by a worker of generatePuzzles.
+type generated struct {
+	index     int   // order of the puzzle in the batch
+	seed      int64 // seed making the puzzle again on its own
+	puzzle    sudoku.Puzzle
+	canonical string
+	err       error
+}
+
+// duplicateTries is the number of duplicates generatePuzzles skips per
+// puzzle asked for, before concluding that the options cannot make as
+// many distinct puzzles.
+const duplicateTries = 10
+
+// generatePuzzles generates count puzzles with workers in parallel,
+// skipping those essentially the same as a previous one: equal once
+// relabeled and transformed, see Grid.Canonical. Each puzzle has its
+// own seed, drawn from opts.Rand, so the batch is the same whatever
+// the number of workers. It returns the number of duplicates skipped,
+// and shows progress on stderr. It gives up with an error when the
+// duplicates exceed duplicateTries per puzzle.
+func generatePuzzles(opts sudoku.GenerateOptions, count int, workers int) ([]sudoku.Puzzle, int, error) {
+	var jobs = make(chan generated)
+	var results = make(chan generated)
+	var done = make(chan struct{})
+	defer close(done)
+
+	go func() {
+		defer close(jobs)
+		for index := 0; ; index++ {
+			select {
+			case jobs <- generated{index: index, seed: opts.Rand.Int63()}:
+			case <-done:
+				return
+			}
+		}
+	}()
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range jobs {
+				var jobOpts = opts
+				jobOpts.Rand = rand.New(rand.NewSource(job.seed))
+				g, err := sudoku.Generate(jobOpts)
+				if (err == nil) {
+					job.puzzle = generatedPuzzle(g, sudoku.Rate(*g), job.seed)
+					job.canonical = g.Canonical()
+				}
+				job.err = err
+				select {
+				case results <- job:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	// results come in any order, they are taken in the order of the
+	// seeds
+	var pending = make(map[int]generated)
+	var next int = 0
+	var seen = make(map[string]bool)
+	var puzzles []sudoku.Puzzle
+	var duplicates int = 0
+	for (len(puzzles) < count) {
+		var result = <-results
+		pending[result.index] = result
+		for (len(puzzles) < count) {
+			result, found := pending[next]
+			if (!found) {
+				break
+			}
+			delete(pending, next)
+			next++
+			if (result.err != nil) {
+				fmt.Fprintln(os.Stderr)
+				return nil, duplicates, result.err
+			}
+			if (seen[result.canonical]) {
+				duplicates++
+				if (duplicates > duplicateTries*count) {
+					fmt.Fprintln(os.Stderr)
+					return nil, duplicates, fmt.Errorf("only %d distinct puzzles found after %d duplicates, widen the options or ask for fewer", len(puzzles), duplicates)
+				}
+				continue
+			}
+			seen[result.canonical] = true
+			puzzles = append(puzzles, result.puzzle)
+			fmt.Fprintf(os.Stderr, "\r%d/%d puzzles", len(puzzles), count)
+		}
+	}
+	return puzzles, duplicates, nil
+}
+
+// seedFlag adds --seed to flags.
+func seedFlag(flags *flag.FlagSet) *int64 {
+	return flags.Int64("seed", 0, "seed of the random choices, to make the same puzzle again (default from the time)")
+}
+
+// newRand returns a source of random numbers seeded with *seed, which
+// is set from the time when 0.
+func newRand(seed *int64) *rand.Rand {
+	if (*seed == 0) {
+		*seed = time.Now().UnixNano()
+	}
+	return rand.New(rand.NewSource(*seed))
+}
+
+// parseDifficulty sets the difficulty of opts from a level name, or a
+// range of scores such as 3-4.5. A single score is a range on its own.
+func parseDifficulty(difficulty string, opts *sudoku.GenerateOptions) error {
+	if (difficulty == "") {
+		return nil
+	}
+	var levels = []string{sudoku.Easy, sudoku.Medium, sudoku.Hard, sudoku.Diabolical}
+	if (slices.Contains(levels, difficulty)) {
+		opts.Level = difficulty
+		return nil
+	}
+
+	low, high, isRange := strings.Cut(difficulty, "-")
+	if (!isRange) {
+		high = low
+	}
+	min, err := strconv.ParseFloat(low, 64)
+	if (err != nil) {
+		return fmt.Errorf("invalid difficulty %q, use %s or a range of scores such as 3-4.5", difficulty, strings.Join(levels, ", "))
+	}
+	max, err := strconv.ParseFloat(high, 64)
+	if (err != nil || max < min) {
+		return fmt.Errorf("invalid difficulty %q, use %s or a range of scores such as 3-4.5", difficulty, strings.Join(levels, ", "))
+	}
+	opts.MinScore, opts.MaxScore = min, max
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// symmetryNames are the values of --symmetry.
+func symmetryNames() string {
+	var names []string
+	for _, symmetry := range sudoku.Symmetries {
+		names = append(names, string(symmetry))
+	}
+	return strings.Join(names, ", ")
+}
+
+// runHarden implements the harden subcommand:
+//   sudoksolv harden [--symmetry S] PUZZLE
+// It prints the puzzle, or full grid, with as many clues removed as
+// possible while its solution stays unique.
+func runHarden(args []string) error {
+	var flags = flag.NewFlagSet("harden", flag.ContinueOnError)
+	var symmetryName = flags.String("symmetry", "none", "pattern the remaining clues keep: "+symmetryNames())
+	var seed = seedFlag(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sudoksolv harden [--symmetry S] PUZZLE")
+		fmt.Fprintln(flags.Output(), "Removes clues from PUZZLE, or a full grid, while its solution stays unique.")
+		flags.PrintDefaults()
+	}
+	err := parseFlags(flags, args)
+	if (err != nil) {
+		return err
+	}
+	if (flags.NArg() != 1) {
+		flags.Usage()
+		return errUsage
+	}
+
+	symmetry, err := sudoku.ParseSymmetry(*symmetryName)
+	if (err != nil) {
+		return fmt.Errorf("%v, use %s", err, symmetryNames())
+	}
+	g, err := sudoku.Parse(flags.Arg(0))
+	if (err != nil) {
+		return err
+	}
+	hardened, err := g.Harden(sudoku.HardenOptions{
+		Symmetry: symmetry,
+		Rand:     newRand(seed),
+	})
+	if (err != nil) {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Removed %d clues, %d left, seed %d.\n", hardened.CountEmptyCells()-g.CountEmptyCells(), 81-hardened.CountEmptyCells(), *seed)
+	fmt.Println(hardened.String())
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// runHint implements the hint subcommand:
+//   sudoksolv hint PUZZLE
+// It prints the next deduction the solver makes on the puzzle, which
+// may be partly solved, without revealing the rest of the solution.
+func runHint(args []string) error {
+	var flags = flag.NewFlagSet("hint", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sudoksolv hint PUZZLE")
+		fmt.Fprintln(flags.Output(), "Prints the next deduction to make on PUZZLE, a cell to fill or an option to remove, and the technique making it.")
+		flags.PrintDefaults()
+	}
+	err := parseFlags(flags, args)
+	if (err != nil) {
+		return err
+	}
+	if (flags.NArg() != 1) {
+		flags.Usage()
+		return errUsage
+	}
+
+	g, err := sudoku.Parse(flags.Arg(0))
+	if (err != nil) {
+		return err
+	}
+	err = g.Validate()
+	if (err != nil) {
+		return err
+	}
+	if (g.CountEmptyCells() == 0) {
+		fmt.Println("The grid is full.")
+		return nil
+	}
+
+	e, _, err := sudoku.NewSolver().NextDeduction(g)
+	if (err != nil) {
+		return fmt.Errorf("no deduction found: %w", err)
+	}
+	fmt.Println(describeDeduction(e))
+	return nil
+}
+
+// describeDeduction returns a sentence telling the deduction, such as
+//   r5c5 is 3, by hidden-single in square 5.
+func describeDeduction(e sudoku.Event) string {
+	var str = fmt.Sprintf("%v is not %d", e.Cell, e.Digit)
+	if (e.Kind == sudoku.Placement) {
+		str = fmt.Sprintf("%v is %d", e.Cell, e.Digit)
+	}
+	str += ", by " + e.Technique
+	if (e.House != "") {
+		str += " in " + e.House
+	}
+	if (e.Reason != "") {
+		str += ": " + e.Reason
+	}
+	return str + "."
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// Verbosity levels, set by -q, -v and -vv. Each prints what the one
+// before does, and more.
+const (
+	levelQuiet   = iota // the solution only
+	levelSummary        // how the solve went, the default
+	levelSteps          // each deduction
+	levelGrids          // the options left after each pass
+)
+
+// verbosity is the level of the messages logger prints.
+var verbosity = levelSummary
+
+// logger prints the messages meant for the user, as opposed to the
+// solution itself.
+var logger = log.New(os.Stdout, "", 0)
+
+// logAt prints a message with logger when the verbosity is at least
+// level.
+func logAt(level int, format string, args ...any) {
+	if (verbosity >= level) {
+		logger.Printf(format, args...)
+	}
+}
+
+// subcommands maps the first command-line argument to the function
+// handling the rest of the arguments.
+var subcommands = map[string]func(args []string) error{
+	"bench":    runBench,
+	"book":     runBook,
+	"check":    runCheck,
+	"convert":  runConvert,
+	"daily":    runDaily,
+	"generate": runGenerate,
+	"harden":   runHarden,
+	"hint":     runHint,
+	"minimal":  runMinimal,
+	"rate":     runRate,
+	"repair":   runRepair,
+	"repl":     runRepl,
+	"solve":    runSolve,
+}
+
+// Exit statuses telling why a puzzle was not solved, by solve as by the
+// other commands. Other failures, such as a file which cannot be read,
+// exit with 1 too.
+const (
+	exitInvalid    = 1 // the clues repeat a value in a house
+	exitNoSolution = 2 // the clues contradict each other
+	exitNotUnique  = 3 // the puzzle has several solutions
+	exitStuck      = 4 // the techniques are not enough, see --logic-only
+	exitTimeout    = 5 // --timeout expired
+)
+
+// errUsage is returned by commands given invalid flags or arguments,
+// once they printed their usage. It exits with 1, and is not printed
+// again.
+var errUsage = errors.New("invalid command line")
+
+// parseFlags parses the arguments of a command with flags, made with
+// flag.ContinueOnError. It returns flag.ErrHelp on -h, and errUsage
+// for an invalid flag, which flags printed with its usage.
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	err := flags.Parse(args)
+	if (err != nil && !errors.Is(err, flag.ErrHelp)) {
+		return errUsage
+	}
+	return err
+}
+
+// exitCode returns the exit status for the error solving a puzzle.
+func exitCode(err error) int {
+	var notUnique *sudoku.NotUniqueError
+	switch {
+	case errors.Is(err, sudoku.ErrInvalidClues):
+		return exitInvalid
+	case errors.Is(err, sudoku.ErrNoSolution):
+		return exitNoSolution
+	case errors.As(err, &notUnique):
+		return exitNotUnique
+	case errors.Is(err, context.DeadlineExceeded):
+		return exitTimeout
+	case errors.Is(err, sudoku.ErrNotSolved):
+		return exitStuck
+	}
+	return 1
+}
+
+// printGuessSuggestion prints the guess recommended for g, if any,
+// trying the values with the strategies of solver. A value whose
+// alternative leads to a contradiction is printed as the elimination it
+// is.
+func printGuessSuggestion(solver *sudoku.Solver, g *sudoku.Grid) {
+	trial, reason, ok := solver.SuggestGuess(g)
+	if (!ok) {
+		logAt(levelSummary, "No guess to suggest: no bivalue cell has a value free of contradiction.")
+		return
+	}
+	if (trial.Eliminated != 0) {
+		logAt(levelSummary, "Elimination, not a guess: remove %d from r%dc%d, because %s, which leaves %d.", trial.Eliminated, trial.Row+1, trial.Col+1, reason, trial.Value)
+		return
+	}
+	logAt(levelSummary, "GUESS, not a deduction: try r%dc%d = %d, because %s.", trial.Row+1, trial.Col+1, trial.Value, reason)
+}
+
+// printReport prints a summary of how the solver went.
+func printReport(report sudoku.SolveReport) {
+	var outcome string = "Solved"
+	if (!report.Solved) {
+		outcome = fmt.Sprintf("Stopped with %d empty cells", report.EmptyCells)
+	}
+	var techniques string = strings.Join(report.Techniques, ", ")
+	if (techniques == "") {
+		techniques = "no technique"
+	}
+	logAt(levelSummary, "%s after %d passes in %v, placing %d cells and eliminating %d options with %s.",
+		outcome, report.Passes, report.Elapsed, report.Placed(), report.Eliminated(), techniques)
+	for _, tally := range report.Stats() {
+		logAt(levelSummary, "  %-17s %3d uses, %2d cells placed, %3d options eliminated", tally.Technique, tally.Uses, tally.Placed, tally.Eliminated)
+	}
+}
+
+// parseFile reads a puzzle from a file. Puzzle formats such as .sdk are
+// recognized by their extension, and their metadata printed and
+// returned. Other files are read with sudoku.ParseLayout.
+func parseFile(path string) (*sudoku.Grid, sudoku.Puzzle, error) {
+	file, err := os.Open(path)
+	if (err != nil) {
+		return nil, sudoku.Puzzle{}, err
+	}
+	defer file.Close()
+
+	var format = sudoku.FormatFromPath(path)
+	if (format == "line") {
+		g, err := sudoku.ParseLayout(file)
+		if (err != nil) {
+			return nil, sudoku.Puzzle{}, fmt.Errorf("%s: %w", path, err)
+		}
+		return g, sudoku.Puzzle{}, nil
+	}
+
+	puzzles, err := sudoku.ReadPuzzles(file, format)
+	if (err != nil) {
+		return nil, sudoku.Puzzle{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if (len(puzzles) != 1) {
+		return nil, sudoku.Puzzle{}, fmt.Errorf("%s: holds %d puzzles, expected one", path, len(puzzles))
+	}
+	printMetadata(puzzles[0])
+	return sudoku.NewGrid(puzzles[0].Cells), puzzles[0], nil
+}
+
+// printMetadata prints what a puzzle file tells about the puzzle.
+func printMetadata(p sudoku.Puzzle) {
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{"Title", p.Title},
+		{"Author", p.Author},
+		{"Description", p.Description},
+		{"Comment", p.Comment},
+		{"Date", p.Date},
+		{"Source", p.Source},
+		{"Difficulty", p.Difficulty},
+		{"URL", p.URL},
+	} {
+		if (field.value != "") {
+			logAt(levelSummary, "%s: %s", field.name, field.value)
+		}
+	}
+}
+
+// printSolutions prints the solutions of a puzzle which has more than
+// one, at most max of them, and returns false for a unique or
+// unsolvable puzzle.
+func printSolutions(g *sudoku.Grid, max int, drawing bool) (bool, error) {
+	var solutions = g.AllSolutions(max)
+	if (len(solutions) < 2) {
+		return false, nil
+	}
+	if (len(solutions) == max) {
+		logAt(levelSummary, "The puzzle is not unique, here are its first %d solutions.", max)
+	} else {
+		logAt(levelSummary, "The puzzle is not unique, it has %d solutions.", len(solutions))
+	}
+	for i := range solutions {
+		var format = sudoku.FormatLine
+		if (drawing) {
+			format = sudoku.FormatGrid
+			logAt(levelSummary, "Solution %d:", i+1)
+		}
+		err := solutions[i].Render(os.Stdout, format)
+		if (err != nil) {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// usage prints how to run sudoksolv, shown on -h and missing puzzle.
+func usage() {
+	var out = flag.CommandLine.Output()
+	fmt.Fprintln(out, `Usage: sudoksolv [solve] [flags] PUZZLE
+       sudoksolv COMMAND [args]
+       sudoksolv help [COMMAND]
+
+PUZZLE is 81 values, row by row, with 0, . or _ for empty cells, or - to
+read them from stdin. A pencil-mark puzzle (sukaku) is given as 729 values,
+nine per cell, see sudoku.ParseSukaku, and a short code made by
+sudoksolv convert --to code, and share links of sites such as sudokuwiki.org
+are accepted too. For example:
+  sudoksolv 006000300435009007701600000870002010000000000060900082000006105900100276007000800
+  echo 006000300435009007701600000870002010000000000060900082000006105900100276007000800 | sudoksolv -
+
+Commands:
+  bench      time solving a file of puzzles
+  book       write a PDF booklet of new puzzles
+  check      check a grid being filled against the solution
+  convert    convert puzzles between file formats
+  daily      print the puzzle of the day
+  generate   make a random puzzle
+  harden     remove clues from a puzzle while it stays unique
+  hint       tell the next deduction to make on a puzzle
+  minimal    list the clues a puzzle does not need
+  rate       rate the difficulty of puzzles
+  repair     add clues to a puzzle having several solutions
+  repl       solve interactively
+  solve      solve a puzzle, the default
+
+Flags of solve:`)
+	flag.PrintDefaults()
+}
+
+// runHelp implements the help command:
+//   sudoksolv help [COMMAND]
+// It prints how to run sudoksolv, or the flags of a command.
+func runHelp(args []string) error {
+	if (len(args) == 0) {
+		flag.CommandLine.SetOutput(os.Stdout)
+		usage()
+		return nil
+	}
+	run, ok := subcommands[args[0]]
+	if (!ok) {
+		return fmt.Errorf("unknown command %q, see sudoksolv help", args[0])
+	}
+	err := run([]string{"-h"})
+	if (errors.Is(err, flag.ErrHelp)) {
+		return nil
+	}
+	return err
+}
+
+// writeFile creates the file at path and writes it with write.
+func writeFile(path string, write func(io.Writer) error) error {
+	file, err := os.Create(path)
+	if (err != nil) {
+		return err
+	}
+	err = write(file)
+	if (err != nil) {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// isTerminal returns true if f is a terminal rather than a file or a
+// pipe.
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	if (err != nil) {
+		return false
+	}
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
+func main() {
+	flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+	flag.Usage = usage
+	// see https://no-color.org
+	sudoku.SetColor(isTerminal(os.Stdout) && os.Getenv("NO_COLOR") == "")
+	var args = os.Args[1:]
+	// without a command, the arguments are those of solve
+	var run = runSolve
+	if (len(args) > 0 && args[0] == "help") {
+		run, args = runHelp, args[1:]
+	} else if (len(args) > 0) {
+		command, ok := subcommands[args[0]]
+		if (ok) {
+			run, args = command, args[1:]
+		}
+	}
+	err := run(args)
+	if (errors.Is(err, flag.ErrHelp) || errors.Is(err, errQuit)) {
+		return
+	}
+	if (err != nil) {
+		if (!errors.Is(err, errUsage)) {
+			log.Print(err)
+		}
+		os.Exit(exitCode(err))
+	}
+}
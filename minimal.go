@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// runMinimal implements the minimal subcommand:
+//   sudoksolv minimal PUZZLE
+// It tells whether every clue of the puzzle is needed for its solution
+// to be unique, and lists the clues which are not.
+func runMinimal(args []string) error {
+	var flags = flag.NewFlagSet("minimal", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sudoksolv minimal PUZZLE")
+		fmt.Fprintln(flags.Output(), "Lists the clues which can each be removed, the puzzle keeping a single solution.")
+		flags.PrintDefaults()
+	}
+	err := parseFlags(flags, args)
+	if (err != nil) {
+		return err
+	}
+	if (flags.NArg() != 1) {
+		flags.Usage()
+		return errUsage
+	}
+
+	g, err := sudoku.Parse(flags.Arg(0))
+	if (err != nil) {
+		return err
+	}
+	redundant, err := g.RedundantClues()
+	if (err != nil) {
+		return err
+	}
+	if (len(redundant) == 0) {
+		fmt.Println("The puzzle is minimal: every clue is needed.")
+		return nil
+	}
+
+	var clues []string
+	for _, cell := range redundant {
+		clues = append(clues, fmt.Sprintf("%v=%d", cell, g.Cells()[cell.Row][cell.Col]))
+	}
+	fmt.Printf("%d redundant clues, each of which can be removed, but maybe not all together: %s\n", len(redundant), strings.Join(clues, ", "))
+	return nil
+}
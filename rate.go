@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// runRate implements the rate subcommand:
+//   sudoksolv rate [PUZZLE...]
+// It prints the difficulty of each puzzle, read one per line from stdin
+// when none is given, without printing their solutions. The error
+// returned is the one of the first puzzle which could not be rated.
+func runRate(args []string) error {
+	var flags = flag.NewFlagSet("rate", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sudoksolv rate [PUZZLE...]")
+		fmt.Fprintln(flags.Output(), "Prints the level, score from 1 to 10 and hardest technique of each puzzle.")
+		flags.PrintDefaults()
+	}
+	err := parseFlags(flags, args)
+	if (err != nil) {
+		return err
+	}
+
+	var puzzles = flags.Args()
+	if (len(puzzles) == 0) {
+		var err error
+		puzzles, err = readLines(os.Stdin)
+		if (err != nil) {
+			return err
+		}
+	}
+
+	var failure error = nil
+	for _, puzzle := range puzzles {
+		g, err := sudoku.Parse(puzzle)
+		if (err != nil) {
+			return fmt.Errorf("%s: %w", puzzle, err)
+		}
+		var rating = sudoku.Rate(*g)
+		if (rating.Level == "") {
+			fmt.Printf("%s %s\n", g.String(), strings.ReplaceAll(rating.Err.Error(), "\n", "; "))
+			if (failure == nil) {
+				failure = fmt.Errorf("%s: %w", puzzle, rating.Err)
+			}
+			continue
+		}
+		fmt.Printf("%s %s %.1f %s\n", g.String(), rating.Level, rating.Score, rating.Hardest)
+	}
+	return failure
+}
+
+// readLines returns the lines of r which are not blank.
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	var scanner = bufio.NewScanner(r)
+	for scanner.Scan() {
+		var line = strings.TrimSpace(scanner.Text())
+		if (line != "") {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
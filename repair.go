@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// runRepair implements the repair subcommand:
+//   sudoksolv repair PUZZLE
+// It prints the puzzle with the clues added, taken from one of its
+// solutions, for it to have a single solution.
+func runRepair(args []string) error {
+	var flags = flag.NewFlagSet("repair", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sudoksolv repair PUZZLE")
+		fmt.Fprintln(flags.Output(), "Adds the fewest clues found for PUZZLE to have a single solution.")
+		flags.PrintDefaults()
+	}
+	err := parseFlags(flags, args)
+	if (err != nil) {
+		return err
+	}
+	if (flags.NArg() != 1) {
+		flags.Usage()
+		return errUsage
+	}
+
+	g, err := sudoku.Parse(flags.Arg(0))
+	if (err != nil) {
+		return err
+	}
+	err = g.Validate()
+	if (err != nil) {
+		return err
+	}
+	repaired, added, err := g.Repair()
+	if (err != nil) {
+		return err
+	}
+
+	if (len(added) == 0) {
+		fmt.Fprintln(os.Stderr, "The puzzle already has a single solution.")
+	} else {
+		var clues []string
+		for _, cell := range added {
+			clues = append(clues, fmt.Sprintf("%v=%d", cell, repaired.Cells()[cell.Row][cell.Col]))
+		}
+		fmt.Fprintf(os.Stderr, "Clues added (%d): %s\n", len(added), strings.Join(clues, ", "))
+	}
+	fmt.Println(repaired.String())
+	return nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+var replHelp = `Commands:
+  load <grid>    load a puzzle of 81 digits (0 or . for empty cells)
+  set r4c7 3     set a cell, 0 clears it
+  candidates     print the candidates of every empty cell
+  hint           tell the next deduction without applying it
+  step           run one pass of the solver
+  undo           revert the last load, set, step or solve
+  solve          run the solver until it is done or stuck
+  print          print the grid
+  help           show this help
+  quit           leave`
+
+var cellRef = regexp.MustCompile(`^[rR]([1-9])[cC]([1-9])$`)
+
+// newReplGrid returns the grid of cells with its options listed, which
+// explains the deductions of step.
+func newReplGrid(cells [9][9]int) *sudoku.Grid {
+	var g = sudoku.NewGrid(cells)
+	g.Verbose = true
+	g.ListOptions()
+	return g
+}
+
+// runRepl implements the repl subcommand, an interactive prompt
+// driving the solver one command at a time.
+func runRepl(args []string) error {
+	var g = newReplGrid([9][9]int{})
+	var history []sudoku.Grid
+	var save = func() {
+		history = append(history, *g)
+	}
+
+	var scanner = bufio.NewScanner(os.Stdin)
+	fmt.Println("sudoksolv " + version + ", type help for the list of commands.")
+	for {
+		fmt.Print("> ")
+		if (!scanner.Scan()) {
+			fmt.Println()
+			return scanner.Err()
+		}
+
+		var fields = strings.Fields(scanner.Text())
+		if (len(fields) == 0) {
+			continue
+		}
+
+		switch fields[0] {
+		case "load":
+			if (len(fields) != 2) {
+				fmt.Println("usage: load <grid>")
+				continue
+			}
+			cells, err := sudoku.ParseCells(fields[1])
+			if (err != nil) {
+				fmt.Println(err)
+				continue
+			}
+			save()
+			g = newReplGrid(cells)
+			g.Render(os.Stdout, sudoku.FormatGrid)
+
+		case "set":
+			if (len(fields) != 3) {
+				fmt.Println("usage: set r4c7 3")
+				continue
+			}
+			var ref = cellRef.FindStringSubmatch(fields[1])
+			value, err := strconv.Atoi(fields[2])
+			if (ref == nil || err != nil || value < 0 || value > 9) {
+				fmt.Println("usage: set r4c7 3")
+				continue
+			}
+			var row, col int = int(ref[1][0] - '1'), int(ref[2][0] - '1')
+			save()
+			g.SetCell(row, col, value)
+			g.Render(os.Stdout, sudoku.FormatHints)
+
+		case "candidates":
+			g.Render(os.Stdout, sudoku.FormatPencilMarks)
+
+		case "hint":
+			e, ok, err := sudoku.NewSolver().NextDeduction(g)
+			if (err != nil) {
+				fmt.Printf("No deduction found: %v.\n", err)
+				continue
+			}
+			if (!ok) {
+				fmt.Println("The grid is full.")
+				continue
+			}
+			fmt.Println(describeDeduction(e))
+
+		case "step":
+			save()
+			changed, err := g.Step()
+			if (err != nil) {
+				fmt.Println(err)
+			} else if (!changed) {
+				fmt.Println("No progress.")
+			}
+			g.Render(os.Stdout, sudoku.FormatHints)
+
+		case "undo":
+			if (len(history) == 0) {
+				fmt.Println("Nothing to undo.")
+				continue
+			}
+			*g = history[len(history)-1]
+			history = history[:len(history)-1]
+			g.Render(os.Stdout, sudoku.FormatHints)
+
+		case "solve":
+			save()
+			var verbose = g.Verbose
+			g.Verbose = false
+			err := g.Solve()
+			g.Verbose = verbose
+			g.Render(os.Stdout, sudoku.FormatHints)
+			if (err != nil) {
+				fmt.Printf("%v, %d empty cells left.\n", err, g.CountEmptyCells())
+			}
+
+		case "print":
+			g.Render(os.Stdout, sudoku.FormatHints)
+
+		case "help":
+			fmt.Println(replHelp)
+
+		case "quit", "exit":
+			return nil
+
+		default:
+			fmt.Printf("Unknown command %q, type help for the list of commands.\n", fields[0])
+		}
+	}
+}
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// The flags of the solve command are those of the command line, so
+// that sudoksolv without a command solves too.
+var resumeState = flag.String("resume-state", "", "resume solving from a stuck-state bundle instead of a puzzle")
+var bundleFile = flag.String("bundle", "", "write the stuck-state bundle to this file when the techniques are stuck, see --logic-only")
+var fromClipboard = flag.Bool("from-clipboard", false, "read the puzzle from the system clipboard")
+var toClipboard = flag.Bool("to-clipboard", false, "copy the solution to the system clipboard as 81 digits")
+var guess = flag.Bool("guess", false, "when stuck, suggest the safest guess to continue")
+var strategies = flag.String("strategies", "", "comma-separated strategies to use, in order (default all but jellyfish and unique-rectangle)")
+var techniques = flag.String("techniques", "", "comma-separated tiers or strategies allowed, applied cheapest first, e.g. singles,pairs,fish (see --strategies for an order of your own)")
+var assumeUnique = flag.Bool("assume-unique", false, "use techniques relying on the puzzle having a single solution, such as unique rectangles")
+var chainDepth = flag.Int("chain-depth", sudoku.DefaultChainDepth, "rounds of singles nishio propagates a trial value for, 0 to turn it off")
+var backend = flag.String("backend", "logic", "how to solve: logic (human techniques, explained), dlx (dancing links search, fast) or sat (see --sat-solver)")
+var satSolver = flag.String("sat-solver", "kissat -q", "SAT solver command of --backend sat, given the formula file as last argument")
+var logicOnly = flag.Bool("logic-only", false, "give up when the solving techniques are stuck, instead of searching the rest of the solution by backtracking")
+var jellyfish = flag.Bool("jellyfish", false, "also look for jellyfish patterns, a slow search rarely needed")
+var allSolutions = flag.Int("all-solutions", 0, "when the puzzle has several solutions, print them all, up to this many, instead of solving it")
+var timeout = flag.Duration("timeout", 0, "give up solving after this duration, e.g. 2s (default no limit)")
+var inputFile = flag.String("input", "", "solve every puzzle of a file, - for lines on stdin, printing one line of results per puzzle (a CSV record with --format csv) and a summary")
+var workers = flag.Int("workers", runtime.GOMAXPROCS(0), "puzzles of --input solved in parallel")
+var puzzleFile = flag.String("f", "", "read the puzzle from a file: one line of 81 values, nine lines of nine, or a drawn grid")
+var outputFormat = flag.String("format", "grid", "output format: "+strings.Join(outputFormats, ", ")+" (grid draws the solving as it goes, the others print the result only)")
+var imageSize = flag.Int("size", 470, "width and height of png and gif images, in pixels")
+var imageDPI = flag.Int("dpi", 0, "resolution stored in png images, in dots per inch (default none)")
+
+// outputFormats are the values of --format.
+var outputFormats = []string{"grid", "line", "csv", "json", "svg", "png", "html", "latex", "marks"}
+var styleName = flag.String("style", "classic", "grid style: compact, classic, spacious, unicode or unicode-compact")
+var gifFile = flag.String("gif", "", "write an animated GIF of the solve, step by step, to this file (--size pixels wide)")
+var gifDelay = flag.Int("gif-delay", 20, "time each step of --gif is shown, in hundredths of a second")
+var castFile = flag.String("cast", "", "write the solve, step by step, as an asciinema cast file")
+var castDelay = flag.Duration("cast-delay", 500*time.Millisecond, "time each step of --cast is shown")
+var logFile = flag.String("log", "", "write the deductions to this file as JSON lines, - for stderr")
+var markLast = flag.Int("mark-last", 0, "highlight the last N cells the solver placed in the solution")
+var noColor = flag.Bool("no-color", false, "do not highlight the output with ANSI colors (default when not a terminal, or NO_COLOR is set)")
+var quiet = flag.Bool("q", false, "print the solution only")
+var verboseFlag = flag.Bool("v", false, "also explain each deduction")
+var veryVerbose = flag.Bool("vv", false, "also explain each deduction, and draw the options left after each pass")
+var stepMode = flag.Bool("step", false, "pause after each pass of the solver, showing its deductions: Enter to go on, a to run to the end, q to quit")
+var labelsName = flag.String("labels", "none", "grid axis labels: none, rc (r1-r9, c1-c9) or alpha (A-I, 1-9)")
+
+// setBackend makes solver solve the way backend tells: logic keeps its
+// strategies, dlx and sat replace them by a search.
+func setBackend(solver *sudoku.Solver, backend string) error {
+	switch backend {
+	case "logic":
+	case "dlx":
+		solver.Strategies = []sudoku.Strategy{sudoku.DLX{}}
+	case "sat":
+		solver.Strategies = []sudoku.Strategy{sudoku.SAT{Command: strings.Fields(*satSolver)}}
+	default:
+		return fmt.Errorf("unknown backend %q, use logic, dlx or sat", backend)
+	}
+	return nil
+}
+
+// runSolve implements the solve command, run when no command is given:
+//   sudoksolv [solve] [flags] PUZZLE
+// It solves the puzzle, printing how, and returns the error telling why
+// when it could not, which main turns into the exit status, see exitCode.
+func runSolve(args []string) error {
+	err := parseFlags(flag.CommandLine, args)
+	if (err != nil) {
+		return err
+	}
+	if (*inputFile == "" && (flag.NArg() > 1 || (flag.Arg(0) == "" && *resumeState == "" && !*fromClipboard && *puzzleFile == ""))) {
+		flag.Usage()
+		return errUsage
+	}
+	if (*noColor) {
+		sudoku.SetColor(false)
+	}
+
+	style, ok := sudoku.Styles[*styleName]
+	if (!ok) {
+		return fmt.Errorf("unknown style %q, use compact, classic, spacious, unicode or unicode-compact", *styleName)
+	}
+	sudoku.SetStyle(style)
+	err = sudoku.SetLabels(*labelsName)
+	if (err != nil) {
+		return err
+	}
+
+	if (!slices.Contains(outputFormats, *outputFormat)) {
+		return fmt.Errorf("unknown format %q, use %s", *outputFormat, strings.Join(outputFormats, ", "))
+	}
+	// only the solution goes to stdout when it is meant for other tools
+	var drawing bool = *outputFormat == "grid"
+	if (!drawing) {
+		logger.SetOutput(os.Stderr)
+	}
+	switch {
+	case *quiet:
+		verbosity = levelQuiet
+	case *veryVerbose:
+		verbosity = levelGrids
+	case *verboseFlag:
+		verbosity = levelSteps
+	}
+	// the deductions of puzzles solved in parallel would be mixed up
+	if (*inputFile != "" && (*logFile != "" || verbosity > levelSummary)) {
+		return errors.New("--log, -v and -vv explain the solving of one puzzle, they do not apply to --input")
+	}
+
+	var solver = sudoku.NewSolver()
+	solver.Backtrack = !*logicOnly
+	if (*strategies != "") {
+		solver.Strategies, err = sudoku.StrategiesByName(strings.Split(*strategies, ","))
+		if (err != nil) {
+			return fmt.Errorf("%v, available strategies are %s", err, strings.Join(sudoku.StrategyNames(), ", "))
+		}
+	}
+	if (*techniques != "") {
+		if (*strategies != "") {
+			return errors.New("use either --strategies or --techniques, not both")
+		}
+		solver.Strategies, err = sudoku.StrategiesByTechnique(strings.Split(*techniques, ","))
+		if (err != nil) {
+			return fmt.Errorf("%v, use tiers (%s) or strategies (%s)", err, strings.Join(sudoku.TierNames(), ", "), strings.Join(sudoku.StrategyNames(), ", "))
+		}
+	}
+	// the depth of nishio trades its power for explanations easy to follow
+	solver.Strategies = slices.DeleteFunc(solver.Strategies, func(strategy sudoku.Strategy) bool {
+		return strategy.Name() == sudoku.Nishio{}.Name() && *chainDepth <= 0
+	})
+	for i, strategy := range solver.Strategies {
+		if (strategy.Name() == sudoku.Nishio{}.Name()) {
+			solver.Strategies[i] = sudoku.Nishio{Depth: *chainDepth}
+		}
+	}
+	err = setBackend(solver, *backend)
+	if (err != nil) {
+		return err
+	}
+	if (*jellyfish && !slices.Contains(solver.Strategies, sudoku.Strategy(sudoku.Jellyfish{}))) {
+		solver.Strategies = append(solver.Strategies, sudoku.Jellyfish{})
+	}
+	if (*assumeUnique && !slices.Contains(solver.Strategies, sudoku.Strategy(sudoku.UniqueRectangle{}))) {
+		solver.Strategies = append(solver.Strategies, sudoku.UniqueRectangle{})
+	}
+	// --strategies keeps the order given, the others apply the cheapest first
+	if (*strategies == "") {
+		sudoku.SortStrategies(solver.Strategies)
+	}
+
+	var solveLog func(sudoku.Event)
+	if (*logFile != "") {
+		var w io.Writer = os.Stderr
+		if (*logFile != "-") {
+			file, err := os.Create(*logFile)
+			if (err != nil) {
+				return err
+			}
+			defer file.Close()
+			w = file
+		}
+		solveLog = sudoku.JSONLines(w)
+	}
+	// deductions are explained by logger rather than the grid
+	var onEvent = func(e sudoku.Event) {
+		if (solveLog != nil) {
+			solveLog(e)
+		}
+		logAt(levelSteps, "%v", e)
+	}
+
+	if (*inputFile != "") {
+		if (*outputFormat != "csv" && *outputFormat != "line" && *outputFormat != "grid") {
+			return errors.New("results of --input are printed as lines or CSV, use --format line or csv")
+		}
+		if (*workers < 1) {
+			return errors.New("--workers must be at least 1")
+		}
+		return solveBatch(os.Stdout, *inputFile, solver, *outputFormat == "csv", *workers)
+	}
+
+	var puzzle string = flag.Arg(0)
+	var g *sudoku.Grid
+	var meta sudoku.Puzzle // metadata carried to the json output
+
+	if (*resumeState != "") {
+		g, puzzle, err = loadBundle(*resumeState)
+		if (err != nil) {
+			return err
+		}
+		if (drawing && verbosity >= levelSummary) {
+			g.Render(logger.Writer(), sudoku.FormatHints)
+		}
+	} else {
+		if (*fromClipboard) {
+			puzzle, err = puzzleFromClipboard()
+			if (err != nil) {
+				return err
+			}
+		}
+		if (*puzzleFile != "") {
+			g, meta, err = parseFile(*puzzleFile)
+		} else if (puzzle == "-") {
+			g, err = sudoku.ParseGrid(os.Stdin)
+		} else if (strings.HasPrefix(puzzle, "http://") || strings.HasPrefix(puzzle, "https://")) {
+			g, err = sudoku.ParseURL(puzzle)
+		} else {
+			g, err = sudoku.Parse(puzzle)
+			if (err != nil) {
+				decoded, decodeErr := sudoku.Decode(puzzle)
+				if (decodeErr == nil) {
+					g, err = decoded, nil
+				}
+			}
+		}
+		if (err != nil) {
+			return err
+		}
+		puzzle = g.String()
+		if (drawing && verbosity >= levelSummary) {
+			g.Render(logger.Writer(), sudoku.FormatGrid)
+		}
+		g.OnEvent = onEvent
+		g.ListOptions()
+		if (meta.Candidates != nil) {
+			_, err = g.NarrowOptions(*meta.Candidates)
+			if (err != nil) {
+				return err
+			}
+		}
+	}
+	err = g.Validate()
+	if (err != nil) {
+		return err
+	}
+	if (meta.Cells == [9][9]int{}) {
+		meta.Cells, _ = sudoku.ParseCells(puzzle)
+	}
+	g.OnEvent = onEvent
+	ctx, quit := context.WithCancelCause(context.Background())
+	defer quit(nil)
+	if (*stepMode) {
+		if (flag.Arg(0) == "-" && *puzzleFile == "" && *resumeState == "") {
+			return errors.New("--step reads its answers from stdin, give the puzzle otherwise")
+		}
+		stepThrough(solver, g, os.Stdin, quit)
+	}
+	if (verbosity >= levelGrids) {
+		solver.OnPass(func(g *sudoku.Grid) {
+			if (g.CountEmptyCells() > 0) {
+				g.Render(logger.Writer(), sudoku.FormatHints)
+			}
+		})
+	}
+
+	if (*allSolutions > 0) {
+		printed, err := printSolutions(g, *allSolutions, drawing)
+		if (printed || err != nil) {
+			return err
+		}
+	}
+
+	if (*timeout > 0) {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	var start = g.Clone()
+	var steps []sudoku.Step
+	if (*gifFile != "" || *castFile != "") {
+		var record = func(step sudoku.Step) {
+			steps = append(steps, step)
+		}
+		solver.OnPlacement(record)
+		solver.OnElimination(record)
+	}
+	var placed []sudoku.Coord
+	solver.OnPlacement(func(step sudoku.Step) {
+		placed = append(placed, sudoku.Coord{Row: step.Row, Col: step.Col})
+	})
+	report, err := solver.SolveContext(ctx, g)
+	if (errors.Is(context.Cause(ctx), errQuit)) {
+		return errQuit
+	}
+	printReport(report)
+	if (*gifFile != "") {
+		gifErr := writeFile(*gifFile, func(w io.Writer) error {
+			return sudoku.WriteGIF(w, start, steps, sudoku.GIFOptions{Size: *imageSize, Delay: *gifDelay})
+		})
+		if (gifErr != nil) {
+			return gifErr
+		}
+	}
+	if (*castFile != "") {
+		castErr := writeFile(*castFile, func(w io.Writer) error {
+			return sudoku.WriteCast(w, start, steps, sudoku.CastOptions{Delay: *castDelay, Timestamp: time.Now()})
+		})
+		if (castErr != nil) {
+			return castErr
+		}
+	}
+	// printed even when stuck: scripts check "solved", and images show
+	// the options left
+	var outErr error
+	switch *outputFormat {
+	case "json":
+		meta.Solution = g.Cells()
+		outErr = sudoku.WriteResultJSON(os.Stdout, meta, report)
+	case "svg":
+		outErr = g.RenderSVG(os.Stdout)
+	case "png":
+		outErr = g.RenderPNG(os.Stdout, sudoku.PNGOptions{Size: *imageSize, DPI: *imageDPI})
+	case "html":
+		outErr = g.RenderHTML(os.Stdout)
+	case "marks":
+		outErr = g.Render(os.Stdout, sudoku.FormatPencilMarks)
+	}
+	if (outErr != nil) {
+		return outErr
+	}
+	if (err != nil) {
+		if (*guess) {
+			printGuessSuggestion(solver, g)
+		}
+		// only a puzzle the techniques are stuck on can be resumed
+		var stuck *sudoku.TechniquesError
+		if (*bundleFile != "" && errors.As(err, &stuck)) {
+			bundleErr := writeBundle(*bundleFile, puzzle, g)
+			if (bundleErr != nil) {
+				log.Printf("Could not write stuck-state bundle: %v", bundleErr)
+			} else {
+				logAt(levelSummary, "Stuck state written to %s", *bundleFile)
+			}
+		}
+		return err
+	}
+
+	switch *outputFormat {
+	case "grid":
+		err = g.RenderSolution(os.Stdout, placed[max(0, len(placed)-*markLast):])
+	case "line":
+		err = g.Render(os.Stdout, sudoku.FormatLine)
+	case "latex":
+		err = g.Render(os.Stdout, sudoku.FormatLaTeX)
+	case "csv":
+		err = sudoku.WritePuzzles(os.Stdout, "csv", []sudoku.Puzzle{{Cells: g.Cells()}})
+	}
+	if (err != nil) {
+		return err
+	}
+
+	if (*toClipboard) {
+		return writeClipboard(g.String())
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"miqwit/sudoksolv/sudoku"
+)
+
+// errQuit is the cause of the solve being cancelled when the user quits
+// stepping through it. main exits with 0 on it.
+var errQuit = errors.New("quit")
+
+// stepThrough makes the solver pause after each pass on g, drawing the
+// grid with the cells the pass changed highlighted and explaining its
+// deductions, until the user types a to run to the end, or q to quit,
+// which cancels the solve with quit and errQuit. Answers are read from
+// in.
+func stepThrough(solver *sudoku.Solver, g *sudoku.Grid, in io.Reader, quit context.CancelCauseFunc) {
+	var input = bufio.NewReader(in)
+	var auto bool = false
+	var steps []sudoku.Step
+	var events []sudoku.Event
+	var record = func(step sudoku.Step) {
+		steps = append(steps, step)
+	}
+	solver.OnPlacement(record)
+	solver.OnElimination(record)
+	var onEvent = g.OnEvent
+	g.OnEvent = func(e sudoku.Event) {
+		if (onEvent != nil) {
+			onEvent(e)
+		}
+		events = append(events, e)
+	}
+
+	solver.OnPass(func(g *sudoku.Grid) {
+		defer func() {
+			steps, events = nil, nil
+		}()
+		if (auto) {
+			return
+		}
+		var w = logger.Writer()
+		var cells []sudoku.Coord
+		for _, step := range steps {
+			cells = append(cells, sudoku.Coord{Row: step.Row, Col: step.Col})
+		}
+		g.RenderSolution(w, cells)
+		for _, step := range steps {
+			fmt.Fprintln(w, describeDeduction(explainStep(step, events)))
+		}
+
+		fmt.Fprint(w, "Enter for the next step, a to run to the end, q to quit: ")
+		line, err := input.ReadString('\n')
+		switch strings.TrimSpace(line) {
+		case "a":
+			auto = true
+		case "q":
+			auto = true
+			quit(errQuit)
+		}
+		if (err != nil) {
+			// no more answers, such as at the end of a file
+			fmt.Fprintln(w)
+			auto = true
+		}
+	})
+}
+
+// explainStep returns the event reporting step, with its house and
+// reason, or an event made of step alone when there is none.
+func explainStep(step sudoku.Step, events []sudoku.Event) sudoku.Event {
+	var cell = sudoku.Coord{Row: step.Row, Col: step.Col}
+	for _, e := range events {
+		if (e.Kind == step.Kind && e.Technique == step.Technique && e.Cell == cell && e.Digit == step.Value) {
+			return e
+		}
+	}
+	return sudoku.Event{Kind: step.Kind, Technique: step.Technique, Cell: cell, Digit: step.Value}
+}
@@ -0,0 +1,93 @@
+package sudoku
+
+import (
+	"context"
+	"errors"
+)
+
+// backtracking is the technique reported for the cells filled by the
+// search Solver.Backtrack enables.
+const backtracking = "backtracking"
+
+// backtrack fills the empty cells of g with the solution found by
+// searchSolution, recording them in report as a last pass. A puzzle
+// having several solutions is left as it is, with a *NotUniqueError,
+// rather than completed to one of them.
+func (s *Solver) backtrack(ctx context.Context, g *Grid, report *SolveReport) error {
+	var solutions = g.solutionsLeft(2)
+	if (len(solutions) > 1) {
+		return &NotUniqueError{solutions}
+	}
+	solution, err := g.searchSolution(ctx)
+	if (err != nil) {
+		return err
+	}
+
+	var progress Progress
+	report.Passes++
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (g.cells[row][col] != 0) {
+				continue
+			}
+			var value = solution.cells[row][col]
+			g.cells[row][col] = value
+			g.options[row][col] = 0
+			progress.add(backtracking, Placement, row, col, value)
+			g.emit(Event{Placement, backtracking, "", Coord{row, col}, value, ""})
+		}
+	}
+	s.notify(progress.Steps)
+	report.add(backtracking, progress)
+	return nil
+}
+
+// searchSolution returns a copy of g completed by a depth-first search
+// over the options of its empty cells, trying first the cell having
+// the fewest. It returns ErrNoSolution when none completes the grid.
+func (g *Grid) searchSolution(ctx context.Context) (*Grid, error) {
+	var trial = g.Clone()
+	trial.Verbose = false
+	trial.OnEvent = nil
+	var nodes int = 0
+	return trial.search(ctx, &nodes)
+}
+
+// search completes g depth first, counting the cells tried in nodes to
+// check ctx now and then.
+func (g *Grid) search(ctx context.Context, nodes *int) (*Grid, error) {
+	if (g.contradiction() != nil) {
+		return nil, ErrNoSolution
+	}
+
+	var best Coord
+	var fewest int = 10
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (g.cells[row][col] == 0 && g.options[row][col].count() < fewest) {
+				best = Coord{row, col}
+				fewest = g.options[row][col].count()
+			}
+		}
+	}
+	if (fewest == 10) {
+		return g, nil
+	}
+
+	*nodes++
+	if (*nodes%1024 == 0) {
+		err := ctx.Err()
+		if (err != nil) {
+			return nil, err
+		}
+	}
+	for _, value := range g.options[best.Row][best.Col].values() {
+		var next = g.Clone()
+		next.place(best, value)
+		solution, err := next.search(ctx, nodes)
+		if (!errors.Is(err, ErrNoSolution)) {
+			return solution, err
+		}
+	}
+	return nil, ErrNoSolution
+}
@@ -0,0 +1,46 @@
+package sudoku
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBacktrackNotUnique(t *testing.T) {
+	g, err := Parse("1" + strings.Repeat("0", 80))
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	g.ListOptions()
+	var solver = NewSolver()
+	solver.Backtrack = true
+	report, err := solver.Solve(g)
+
+	var notUnique *NotUniqueError
+	if (!errors.As(err, &notUnique)) {
+		t.Fatalf("got %v, want a *NotUniqueError", err)
+	}
+	if (len(notUnique.Solutions) != 2) {
+		t.Errorf("got %d solutions, want 2", len(notUnique.Solutions))
+	}
+	if (report.Solved || g.CountEmptyCells() == 0) {
+		t.Errorf("the grid was completed to %s", g.String())
+	}
+}
+
+func TestBacktrackUnique(t *testing.T) {
+	for _, c := range concurrencyPuzzles {
+		g, err := Parse(c.puzzle)
+		if (err != nil) {
+			t.Fatal(err)
+		}
+		g.ListOptions()
+		var solver = &Solver{Strategies: []Strategy{NakedSingle{}}, Backtrack: true}
+		_, err = solver.Solve(g)
+		if (err != nil) {
+			t.Errorf("%s: %v", c.puzzle, err)
+		} else if (g.String() != c.solution) {
+			t.Errorf("%s: got %s, want %s", c.puzzle, g.String(), c.solution)
+		}
+	}
+}
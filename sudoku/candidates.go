@@ -0,0 +1,74 @@
+package sudoku
+
+import (
+	"fmt"
+)
+
+// Candidates returns the options of a cell: the values it can still
+// hold. Filled cells have none.
+func (g *Grid) Candidates(row int, col int) []int {
+	return g.options[row][col].values()
+}
+
+// HasCandidate returns true if value is an option of the cell.
+func (g *Grid) HasCandidate(row int, col int, value int) bool {
+	return value >= 1 && value <= 9 && g.options[row][col].has(value)
+}
+
+// Eliminate removes value from the options of the cell, and returns
+// true if it was there.
+func (g *Grid) Eliminate(row int, col int, value int) bool {
+	if (!g.HasCandidate(row, col, value)) {
+		return false
+	}
+	g.options[row][col] &^= 1 << value
+	return true
+}
+
+// SetCandidate adds value to the options of an empty cell. Values
+// outside 1 to 9 and filled cells are ignored.
+func (g *Grid) SetCandidate(row int, col int, value int) {
+	if (value < 1 || value > 9 || g.cells[row][col] != 0) {
+		return
+	}
+	g.options[row][col] |= 1 << value
+}
+
+// checkCandidates returns an error naming the first cell holding a
+// candidate which is not a number from 1 to 9.
+func checkCandidates(candidates [9][9][]int) error {
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			for _, value := range candidates[row][col] {
+				if (value < 1 || value > 9) {
+					return fmt.Errorf("not valid candidates, %v holds %d, candidates must be numbers from 1 to 9", Coord{row, col}, value)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// NarrowOptions removes from the options of every empty cell the values
+// missing from its known candidates, such as pencil marks given along
+// with the puzzle. Cells without known candidates are left as they are.
+// It returns the number of options removed, or an error leaving the
+// grid unchanged when a known candidate is not a number from 1 to 9.
+func (g *Grid) NarrowOptions(known [9][9][]int) (int, error) {
+	err := checkCandidates(known)
+	if (err != nil) {
+		return 0, err
+	}
+	var removed int = 0
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (g.cells[row][col] != 0 || len(known[row][col]) == 0) {
+				continue
+			}
+			var unknown = g.options[row][col] &^ digitSetOf(known[row][col])
+			g.options[row][col] &^= unknown
+			removed += unknown.count()
+		}
+	}
+	return removed, nil
+}
@@ -0,0 +1,214 @@
+package sudoku
+
+import (
+	"slices"
+)
+
+// linePermutations are the orders of the rows, or columns, keeping
+// them in their bands: 6 orders of the bands, times 6 orders of the
+// lines in each band. Each maps a new line to the line it comes from.
+var linePermutations = func() [][9]int {
+	var orders = [6][3]int{{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0}}
+	var permutations [][9]int
+	for _, bands := range orders {
+		for _, first := range orders {
+			for _, second := range orders {
+				for _, third := range orders {
+					var perm [9]int
+					for i, within := range [3][3]int{first, second, third} {
+						for j := 0; j < 3; j++ {
+							perm[i*3+j] = bands[i]*3 + within[j]
+						}
+					}
+					permutations = append(permutations, perm)
+				}
+			}
+		}
+	}
+	return permutations
+}()
+
+// Canonical returns the canonical form of the grid values, the same for
+// all the grids the transformations of transform.go and relabeling the
+// values make of it: the smallest 81 digits string among them, with
+// values numbered by order of first appearance. Two puzzles are
+// essentially the same when their canonical forms are equal.
+//
+// The string is built row by row (minlex): only the orders of the
+// lines giving the smallest rows so far are carried on to the next row,
+// so that most are dropped on their first row larger than the best.
+func (g *Grid) Canonical() string {
+	var transposed [9][9]int
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			transposed[row][col] = g.cells[col][row]
+		}
+	}
+
+	var states []minlexState
+	for _, cells := range []*[9][9]int{&g.cells, &transposed} {
+		var rows = newMinlexRows(cells)
+		for _, cols := range distinctColumnOrders(cells) {
+			states = append(states, minlexState{rows: rows, cols: cols, next: '1'})
+		}
+	}
+
+	var canonical = make([]byte, 0, 81)
+	for n := 0; n < 9; n++ {
+		var best []byte
+		var kept []minlexState
+		for _, state := range states {
+			for _, row := range state.nextRows(n) {
+				var next = state
+				var str = next.place(n, row)
+				var order = compareBytes(str, best)
+				if (order < 0) {
+					best, kept = str, nil
+				}
+				if (order <= 0) {
+					kept = append(kept, next)
+				}
+			}
+		}
+		canonical = append(canonical, best...)
+		states = kept
+	}
+	return string(canonical)
+}
+
+// minlexRows are the rows of a grid Canonical orders, with the first
+// row of the same band holding the same values as each, if any: such
+// rows can be swapped without changing anything.
+type minlexRows struct {
+	cells *[9][9]int
+	same  [9]int
+}
+
+func newMinlexRows(cells *[9][9]int) *minlexRows {
+	var rows = &minlexRows{cells: cells}
+	for row := 0; row < 9; row++ {
+		rows.same[row] = row
+		for other := row / 3 * 3; other < row; other++ {
+			if (cells[other] == cells[row]) {
+				rows.same[row] = other
+				break
+			}
+		}
+	}
+	return rows
+}
+
+// distinctColumnOrders returns the orders of the columns of
+// linePermutations giving different grids, dropping those which only
+// swap columns holding the same values.
+func distinctColumnOrders(cells *[9][9]int) [][9]int {
+	var columns [9][9]int
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			columns[col][row] = cells[row][col]
+		}
+	}
+	var class [9]int
+	for col := 0; col < 9; col++ {
+		class[col] = col
+		for other := 0; other < col; other++ {
+			if (columns[other] == columns[col]) {
+				class[col] = other
+				break
+			}
+		}
+	}
+
+	var seen = make(map[[9]int]bool)
+	var orders [][9]int
+	for _, cols := range linePermutations {
+		var key [9]int
+		for i, col := range cols {
+			key[i] = class[col]
+		}
+		if (!seen[key]) {
+			seen[key] = true
+			orders = append(orders, cols)
+		}
+	}
+	return orders
+}
+
+// minlexState is an order of the columns, with the rows placed so far
+// in the order Canonical builds, and the labels given to the values they
+// hold.
+type minlexState struct {
+	rows   *minlexRows
+	cols   [9]int
+	order  [9]int // the first rows, in their new order
+	labels [10]byte
+	next   byte
+}
+
+// nextRows returns the rows which may come at position n, after the
+// first n rows of the state: another row of the same band, or the
+// first row of another band. Of rows holding the same values in a band,
+// only the first is returned.
+func (s *minlexState) nextRows(n int) []int {
+	var used [9]bool
+	for _, row := range s.order[:n] {
+		used[row] = true
+	}
+	var bands = []int{0, 1, 2}
+	if (n%3 != 0) {
+		bands = []int{s.order[n-1] / 3}
+	} else {
+		bands = slices.DeleteFunc(bands, func(band int) bool {
+			return used[band*3]
+		})
+	}
+
+	var rows []int
+	for _, band := range bands {
+		for row := band * 3; row < band*3+3; row++ {
+			var same = s.rows.same[row]
+			if (!used[row] && (same == row || used[same])) {
+				rows = append(rows, row)
+			}
+		}
+	}
+	return rows
+}
+
+// place puts row at position n and returns its string, in the order of
+// the columns, numbering values by first appearance.
+func (s *minlexState) place(n int, row int) []byte {
+	s.order[n] = row
+	var str = make([]byte, 9)
+	for i, col := range s.cols {
+		str[i] = label(s.rows.cells[row][col], &s.labels, &s.next)
+	}
+	return str
+}
+
+// label returns the character of value, numbering values from next by
+// order of first appearance. Empty cells are '0'.
+func label(value int, labels *[10]byte, next *byte) byte {
+	if (value == 0) {
+		return '0'
+	}
+	if (labels[value] == 0) {
+		labels[value] = *next
+		*next++
+	}
+	return labels[value]
+}
+
+// compareBytes compares a and b like bytes.Compare, nil b being larger
+// than everything.
+func compareBytes(a []byte, b []byte) int {
+	if (b == nil) {
+		return -1
+	}
+	for i := range a {
+		if (a[i] != b[i]) {
+			return int(a[i]) - int(b[i])
+		}
+	}
+	return 0
+}
@@ -0,0 +1,63 @@
+package sudoku
+
+import (
+	"testing"
+)
+
+func TestCanonical(t *testing.T) {
+	var puzzles = []string{
+		"004209000900000020000367000500000710100080903600903000070000501000001040800000000",
+		"734259186965148327218367495593426718142785963687913254476892531359671842821534679",
+		// grids whose lines are much alike, which the first rows do not
+		// tell apart
+		"100000000000000000000000000000000000000000000000000000000000000000000000000000002",
+		"000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+	}
+	for _, puzzle := range puzzles {
+		g, err := Parse(puzzle)
+		if (err != nil) {
+			t.Fatal(err)
+		}
+		var canonical = g.Canonical()
+		if (len(canonical) != 81) {
+			t.Fatalf("%s: canonical form %q", puzzle, canonical)
+		}
+
+		// the second and third rows swapped
+		var cells = g.Cells()
+		cells[1], cells[2] = cells[2], cells[1]
+		relabeled, err := g.PermuteDigits([9]int{9, 8, 7, 6, 5, 4, 3, 2, 1})
+		if (err != nil) {
+			t.Fatal(err)
+		}
+		var equivalent = map[string]*Grid{
+			"rotated":    g.Rotate90(),
+			"mirrored":   g.Mirror(),
+			"transposed": g.Transpose(),
+			"bands":      g.SwapBands(0, 2),
+			"stacks":     g.SwapStacks(1, 2).Rotate90(),
+			"rows":       NewGrid(cells),
+			"relabeled":  relabeled,
+			"canonical":  mustParse(t, canonical),
+		}
+		for name, other := range equivalent {
+			if (other.Canonical() != canonical) {
+				t.Errorf("%s: %s grid has canonical form %s, want %s", puzzle, name, other.Canonical(), canonical)
+			}
+		}
+	}
+
+	// one more clue makes another puzzle
+	a, b := mustParse(t, puzzles[0]), mustParse(t, "734209000900000020000367000500000710100080903600903000070000501000001040800000000")
+	if (a.Canonical() == b.Canonical()) {
+		t.Errorf("puzzles of 24 and 26 clues have the same canonical form %s", a.Canonical())
+	}
+}
+
+func mustParse(t *testing.T, str string) *Grid {
+	g, err := Parse(str)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	return g
+}
@@ -0,0 +1,103 @@
+package sudoku
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CastOptions tell how WriteCast plays the solve.
+type CastOptions struct {
+	// Delay is the time each step is shown.
+	Delay time.Duration
+
+	// Timestamp is the time of the recording stored in the header,
+	// left out when zero.
+	Timestamp time.Time
+}
+
+// castHeader is the first line of an asciicast v2 file.
+type castHeader struct {
+	Version   int   `json:"version"`
+	Width     int   `json:"width"`
+	Height    int   `json:"height"`
+	Timestamp int64 `json:"timestamp,omitempty"`
+}
+
+// String returns the step in the usual notation: r3c1=6 for a
+// placement and r3c1<>5 for an elimination, followed by its technique.
+func (s Step) String() string {
+	var op = "="
+	if (s.Kind == Elimination) {
+		op = "<>"
+	}
+	return fmt.Sprintf("%v%s%d (%s)", Coord{s.Row, s.Col}, op, s.Value, s.Technique)
+}
+
+// WriteCast writes the solve as an asciinema cast file (asciicast v2),
+// to replay with asciinema play or embed in documentation. Each frame
+// clears the screen and draws the grid as in Verbose mode, starting
+// from start and applying one of the steps recorded from the solver,
+// with OnPlacement and OnElimination, which is written below it. The
+// grid is drawn with the current style, in colors.
+func WriteCast(w io.Writer, start *Grid, steps []Step, opts CastOptions) error {
+	if (opts.Delay <= 0) {
+		return errors.New("cast: the delay between steps must be positive")
+	}
+
+	settingsMu.RLock()
+	var r = settings
+	settingsMu.RUnlock()
+
+	var frames []string
+	var g = start.Clone()
+	var width, height int = 0, 0
+	var addFrame = func(caption string) {
+		var buf bytes.Buffer
+		var plain = r
+		plain.color = false
+		g.renderGrid(&buf, plain, FormatHints, nil)
+		fmt.Fprintln(&buf, caption)
+		for _, line := range strings.Split(buf.String(), "\n") {
+			width = max(width, utf8.RuneCountInString(line))
+		}
+		height = max(height, strings.Count(buf.String(), "\n")+1)
+
+		buf.Reset()
+		r.color = true
+		g.renderGrid(&buf, r, FormatHints, nil)
+		fmt.Fprintln(&buf, caption)
+		// the terminal is raw when replaying: lines need a carriage return
+		frames = append(frames, "\033[H\033[2J"+strings.ReplaceAll(buf.String(), "\n", "\r\n"))
+	}
+
+	addFrame(fmt.Sprintf("%d empty cells", g.CountEmptyCells()))
+	for _, step := range steps {
+		g.replay(step)
+		addFrame(step.String())
+	}
+	addFrame(fmt.Sprintf("%d empty cells left", g.CountEmptyCells()))
+
+	var header = castHeader{Version: 2, Width: width, Height: height}
+	if (!opts.Timestamp.IsZero()) {
+		header.Timestamp = opts.Timestamp.Unix()
+	}
+	var encoder = json.NewEncoder(w)
+	err := encoder.Encode(header)
+	if (err != nil) {
+		return err
+	}
+	for i, frame := range frames {
+		var at = (time.Duration(i) * opts.Delay).Seconds()
+		err = encoder.Encode([]any{at, "o", frame})
+		if (err != nil) {
+			return err
+		}
+	}
+	return nil
+}
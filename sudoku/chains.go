@@ -0,0 +1,72 @@
+package sudoku
+
+import (
+	"fmt"
+)
+
+// DefaultChainDepth is the number of propagation rounds Nishio tries
+// when its Depth is 0.
+const DefaultChainDepth = 8
+
+// Nishio tries each option of the empty cells in turn: it places the
+// value, propagates singles for at most Depth rounds, and removes the
+// option when a contradiction surfaces. Deeper searches find more
+// eliminations, which are harder to follow by hand.
+type Nishio struct {
+	Depth int // rounds of singles after the trial placement, 0 for DefaultChainDepth
+}
+
+func (Nishio) Name() string {
+	return "nishio"
+}
+
+func (n Nishio) Apply(g *Grid) (Progress, error) {
+	var depth = n.Depth
+	if (depth <= 0) {
+		depth = DefaultChainDepth
+	}
+
+	var progress Progress
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (g.cells[row][col] != 0) {
+				continue
+			}
+			for _, value := range g.Candidates(row, col) {
+				var rounds, contradiction = g.tryCandidate(Coord{row, col}, value, depth)
+				if (contradiction != "") {
+					var reason = fmt.Sprintf("after %d rounds of singles, %s", rounds, contradiction)
+					g.eliminate(&progress, n.Name(), Coord{row, col}, value, "", reason)
+				}
+			}
+		}
+	}
+	return progress, nil
+}
+
+// tryCandidate places value in cell on a copy of the grid, and fills
+// naked and hidden singles for at most depth rounds. It returns the
+// contradiction found and after how many rounds, or an empty string.
+func (g *Grid) tryCandidate(cell Coord, value int, depth int) (int, string) {
+	var trial = g.Clone()
+	trial.Verbose = false
+	trial.OnEvent = nil
+	trial.place(cell, value)
+
+	for round := 0; round <= depth; round++ {
+		var contradiction = trial.contradiction()
+		if (contradiction != nil) {
+			return round, contradiction.Reason
+		}
+		if (round == depth) {
+			break
+		}
+		trial.reduceOptionsFromUniqueOccurence()
+		var filled = trial.fillSecuredOptions()
+		if (len(filled) == 0) {
+			break
+		}
+		trial.removeFromPeers(filled)
+	}
+	return 0, ""
+}
@@ -0,0 +1,34 @@
+package sudoku
+
+import (
+	"testing"
+)
+
+// nishioPuzzle needs nishio once the simpler techniques are stuck.
+const nishioPuzzle = "814030020020000007500040080900080000060200005200100900046900000005001300000008050"
+
+const nishioSolution = "814537629623819547579642183951786234468293715237154968346925871785461392192378456"
+
+func TestNishio(t *testing.T) {
+	var eliminated [2]int
+	for i, strategy := range []Nishio{{Depth: 1}, {}} {
+		g, err := Parse(nishioPuzzle)
+		if (err != nil) {
+			t.Fatal(err)
+		}
+		g.ListOptions()
+		progress, err := strategy.Apply(g)
+		if (err != nil) {
+			t.Fatal(err)
+		}
+		for _, step := range progress.Steps {
+			if (step.Kind != Elimination || int(nishioSolution[step.Row*9+step.Col]-'0') == step.Value) {
+				t.Errorf("depth %d: wrong step %+v", strategy.Depth, step)
+			}
+		}
+		eliminated[i] = progress.Eliminated
+	}
+	if (eliminated[0] == 0 || eliminated[0] >= eliminated[1]) {
+		t.Errorf("eliminated %d options at depth 1 and %d at depth %d, want fewer at depth 1", eliminated[0], eliminated[1], DefaultChainDepth)
+	}
+}
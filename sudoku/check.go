@@ -0,0 +1,61 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CheckResult tells how a grid a player is filling compares with the
+// solution of its puzzle.
+type CheckResult struct {
+	Filled    int     // cells filled beyond the clues of the puzzle
+	Empty     int     // cells left to fill
+	Wrong     []Coord // filled cells not holding the value of the solution
+	Conflicts error   // values repeated in a house, see Validate, or nil
+}
+
+// Correct returns true if no filled cell is wrong.
+func (r CheckResult) Correct() bool {
+	return len(r.Wrong) == 0
+}
+
+// Check compares entries, the grid as far as a player filled it, with
+// the unique solution of the puzzle g, without telling the solution.
+// It returns ErrNoSolution or a *NotUniqueError when g does not have a
+// single solution, and an error when entries change clues of g.
+func (g *Grid) Check(entries *Grid) (CheckResult, error) {
+	var result CheckResult
+	var errs []error
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			var clue = g.cells[row][col]
+			var entry = entries.cells[row][col]
+			if (clue != 0 && entry == 0) {
+				errs = append(errs, fmt.Errorf("%v is %d in the puzzle, not empty", Coord{row, col}, clue))
+			} else if (clue != 0 && entry != clue) {
+				errs = append(errs, fmt.Errorf("%v is %d in the puzzle, not %d", Coord{row, col}, clue, entry))
+			}
+		}
+	}
+	if (len(errs) > 0) {
+		return result, errors.Join(errs...)
+	}
+
+	var solutions = g.AllSolutions(2)
+	if (len(solutions) == 0) {
+		return result, ErrNoSolution
+	}
+	if (len(solutions) > 1) {
+		return result, &NotUniqueError{solutions}
+	}
+
+	result.Empty = entries.CountEmptyCells()
+	result.Filled = g.CountEmptyCells() - result.Empty
+	result.Conflicts = entries.Validate()
+	for _, change := range Diff(solutions[0], *entries) {
+		if (change.To != 0) {
+			result.Wrong = append(result.Wrong, change.Coord)
+		}
+	}
+	return result, nil
+}
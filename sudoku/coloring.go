@@ -0,0 +1,310 @@
+package sudoku
+
+import (
+	"fmt"
+)
+
+// candidate is a value which may go in a cell.
+type candidate struct {
+	Cell  Coord
+	Digit int
+}
+
+// String returns the candidate as r1c1=5.
+func (c candidate) String() string {
+	return fmt.Sprintf("%v=%d", c.Cell, c.Digit)
+}
+
+// linkGraph holds strong links between candidates: exactly one of two
+// linked candidates is true.
+type linkGraph struct {
+	links map[candidate][]candidate
+	nodes []candidate // in the order they were linked, to color them in a stable order
+}
+
+func newLinkGraph() *linkGraph {
+	return &linkGraph{links: make(map[candidate][]candidate)}
+}
+
+// add links a and b.
+func (l *linkGraph) add(a candidate, b candidate) {
+	for _, node := range []candidate{a, b} {
+		_, seen := l.links[node]
+		if (!seen) {
+			l.nodes = append(l.nodes, node)
+		}
+	}
+	l.links[a] = append(l.links[a], b)
+	l.links[b] = append(l.links[b], a)
+}
+
+// coloring gives each candidate of a chain of strong links one of two
+// colors, 0 or 1, alternating along the links: all the candidates of
+// one color are true, and all those of the other false.
+type coloring struct {
+	colors map[candidate]int
+	nodes  []candidate  // in the order they were colored
+	first  [2]candidate // a candidate of each color, to name it
+}
+
+// name describes a color of the chain by one of its candidates.
+func (c coloring) name(color int) string {
+	return fmt.Sprintf("the color of %v", c.first[color])
+}
+
+// colorComponents colors each connected chain of the graph.
+func (l *linkGraph) colorComponents() []coloring {
+	var colored = make(map[candidate]bool)
+	var components []coloring
+	for _, start := range l.nodes {
+		if (colored[start]) {
+			continue
+		}
+		var c = coloring{colors: map[candidate]int{start: 0}, nodes: []candidate{start}}
+		c.first[0] = start
+		colored[start] = true
+		var queue = []candidate{start}
+		for (len(queue) > 0) {
+			var node = queue[0]
+			queue = queue[1:]
+			for _, next := range l.links[node] {
+				if (colored[next]) {
+					continue
+				}
+				colored[next] = true
+				c.colors[next] = 1 - c.colors[node]
+				c.nodes = append(c.nodes, next)
+				if (c.first[1] == (candidate{})) {
+					c.first[1] = next // the first neighbor of start
+				}
+				queue = append(queue, next)
+			}
+		}
+		components = append(components, c)
+	}
+	return components
+}
+
+// Medusa colors chains of strong links between candidates, in the
+// same cell (the two options of a cell) or the same house (the two
+// places of a value), across all values: 3D Medusa. When a color leads
+// to a contradiction, all its candidates are removed. Otherwise, the
+// candidates which would be false whichever color is true are.
+type Medusa struct{}
+
+func (Medusa) Name() string {
+	return "3d-medusa"
+}
+
+func (m Medusa) Apply(g *Grid) (Progress, error) {
+	var progress Progress
+	var graph = newLinkGraph()
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			var options = g.options[row][col].values()
+			if (g.cells[row][col] == 0 && len(options) == 2) {
+				graph.add(candidate{Coord{row, col}, options[0]}, candidate{Coord{row, col}, options[1]})
+			}
+		}
+	}
+	for _, house := range houses {
+		for value := 1; value <= 9; value++ {
+			var places = g.placesOf(house, value)
+			if (len(places) == 2) {
+				graph.add(candidate{places[0], value}, candidate{places[1], value})
+			}
+		}
+	}
+
+	for _, chain := range graph.colorComponents() {
+		if (len(chain.colors) < 4) {
+			continue // a single link tells nothing more than singles
+		}
+		var color, trap = g.medusaContradiction(chain)
+		if (trap != "") {
+			var reason = fmt.Sprintf("if %s is true, %s", chain.name(color), trap)
+			for _, node := range chain.nodes {
+				if (chain.colors[node] == color) {
+					g.eliminate(&progress, m.Name(), node.Cell, node.Digit, "", reason)
+				}
+			}
+			continue
+		}
+
+		for row := 0; row < 9; row++ {
+			for col := 0; col < 9; col++ {
+				for _, value := range g.options[row][col].values() {
+					var node = candidate{Coord{row, col}, value}
+					_, ok := chain.colors[node]
+					if (ok) {
+						continue
+					}
+					var reason = g.medusaTrap(chain, node)
+					if (reason != "") {
+						g.eliminate(&progress, m.Name(), node.Cell, value, "", reason)
+					}
+				}
+			}
+		}
+	}
+	return progress, nil
+}
+
+// medusaContradiction returns a color of the chain which cannot be
+// true, and the contradiction it leads to, or an empty string.
+func (g *Grid) medusaContradiction(chain coloring) (int, string) {
+	for color := 0; color < 2; color++ {
+		var inCell = make(map[Coord]candidate)
+		for _, node := range chain.nodes {
+			if (chain.colors[node] != color) {
+				continue
+			}
+			other, twice := inCell[node.Cell]
+			if (twice) {
+				return color, fmt.Sprintf("%v holds both %d and %d", node.Cell, other.Digit, node.Digit)
+			}
+			inCell[node.Cell] = node
+			if (chain.sees(node, color)) {
+				return color, fmt.Sprintf("%v and a cell it sees both hold %d", node.Cell, node.Digit)
+			}
+		}
+
+		// a cell none of whose options is left
+		for row := 0; row < 9; row++ {
+			for col := 0; col < 9; col++ {
+				var cell = Coord{row, col}
+				_, holds := inCell[cell]
+				if (g.cells[row][col] != 0 || holds || g.options[row][col].count() == 0) {
+					continue
+				}
+				var emptied bool = true
+				for _, value := range g.options[row][col].values() {
+					c, ok := chain.colors[candidate{cell, value}]
+					if ((!ok || c == color) && !chain.sees(candidate{cell, value}, color)) {
+						emptied = false
+					}
+				}
+				if (emptied) {
+					return color, fmt.Sprintf("%v has no option left", cell)
+				}
+			}
+		}
+	}
+	return 0, ""
+}
+
+// witness returns a candidate of the chain with the given color which
+// rules node out, holding another value in its cell or its value in a
+// cell it sees, or false.
+func (c coloring) witness(node candidate, color int) (candidate, bool) {
+	for _, other := range c.nodes {
+		if (c.colors[other] != color) {
+			continue
+		}
+		if (other.Cell == node.Cell && other.Digit != node.Digit) {
+			return other, true
+		}
+		if (other.Digit == node.Digit && other.Cell.Sees(node.Cell)) {
+			return other, true
+		}
+	}
+	return candidate{}, false
+}
+
+// sees returns true if a candidate of the chain with the given color
+// has the same value as node in a cell node sees.
+func (c coloring) sees(node candidate, color int) bool {
+	for _, other := range c.nodes {
+		if (c.colors[other] == color && other.Digit == node.Digit && other.Cell.Sees(node.Cell)) {
+			return true
+		}
+	}
+	return false
+}
+
+// medusaTrap explains why the uncolored node is false whichever color
+// of the chain is true, or returns an empty string.
+func (g *Grid) medusaTrap(chain coloring, node candidate) string {
+	first, ok := chain.witness(node, 0)
+	if (!ok) {
+		return ""
+	}
+	second, ok := chain.witness(node, 1)
+	if (!ok) {
+		return ""
+	}
+	return fmt.Sprintf("either %v or %v is true", first, second)
+}
+
+// RemotePairs colors chains of cells having the same two options, each
+// seeing the next: the colors alternate the two values along the
+// chain. A cell seeing two cells of different colors sees both values,
+// which are removed from its options.
+type RemotePairs struct{}
+
+func (RemotePairs) Name() string {
+	return "remote-pairs"
+}
+
+func (r RemotePairs) Apply(g *Grid) (Progress, error) {
+	var progress Progress
+	// the cells of a pair are linked through their first value
+	var graph = newLinkGraph()
+	var pairs = make(map[candidate]digitSet)
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			var options = g.options[row][col]
+			if (g.cells[row][col] != 0 || options.count() != 2) {
+				continue
+			}
+			var node = candidate{Coord{row, col}, options.first()}
+			pairs[node] = options
+			for _, peer := range peers[row][col] {
+				var other = candidate{peer, options.first()}
+				if (peer.Row*9+peer.Col < row*9+col && pairs[other] == options) {
+					graph.add(other, node)
+				}
+			}
+		}
+	}
+
+	for _, chain := range graph.colorComponents() {
+		if (len(chain.nodes) < 4) {
+			continue // shorter chains are naked pairs
+		}
+		var pair = pairs[chain.nodes[0]].values()
+		for row := 0; row < 9; row++ {
+			for col := 0; col < 9; col++ {
+				var cell = Coord{row, col}
+				_, inChain := chain.colors[candidate{cell, pair[0]}]
+				if (inChain || g.cells[row][col] != 0) {
+					continue
+				}
+				first, ok := chain.seenCell(cell, 0)
+				if (!ok) {
+					continue
+				}
+				second, ok := chain.seenCell(cell, 1)
+				if (!ok) {
+					continue
+				}
+				var reason = fmt.Sprintf("%v and %v hold %d and %d, in one order or the other", first, second, pair[0], pair[1])
+				for _, value := range pair {
+					g.eliminate(&progress, r.Name(), cell, value, "", reason)
+				}
+			}
+		}
+	}
+	return progress, nil
+}
+
+// seenCell returns a cell of the chain with the given color which cell
+// sees, or false.
+func (c coloring) seenCell(cell Coord, color int) (Coord, bool) {
+	for _, node := range c.nodes {
+		if (c.colors[node] == color && node.Cell.Sees(cell)) {
+			return node.Cell, true
+		}
+	}
+	return Coord{}, false
+}
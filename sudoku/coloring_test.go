@@ -0,0 +1,78 @@
+package sudoku
+
+import (
+	"testing"
+)
+
+// medusaTrapPosition is the pencil marks of a puzzle at the point where
+// 3D Medusa is the next step:
+//   .3..2..4.......5...258.......4..26.9.1...9...6..1.....96..3...84...5..61..8...2..
+// r1c7=1 and r1c7=9 have opposite colors in a chain, so one of them is
+// true and 8 leaves r1c7.
+const medusaTrapPosition = "1.....78...3...........6..9....567...2.......1...567..1......89...4..........67..1.....78....4..........6..9.....67..1....6..91.3..67......5.....23....8..23..6...1.....7...2...........5...........8.1..4.6..91.34.67..1.......9..3...7....3..67......5...........8....4.......3............7...2............6...1................9.2.......1..........3...7.....456......4.6.8.........9......78...3...78...345.........6...........9..3...7..1...........4...8....45.......4..78..23.......2345.7..........9.....6...1.........2.........3.........4..7.....4..7......5...........8....4...........7...2...............9....5...........8...3...........6...1..........3..........5...........8....4.67..1..4.6...1..4.67...2...............9...4..7.."
+
+// medusaContradictionPosition is the pencil marks of a puzzle at the
+// point where 3D Medusa is the next step:
+//   46...........8...3.....31..54.7..9.69...3.....1...8..4....2....2......4....6..5.9
+// The color of r3c8=9 puts both 4 and 6 in r3c5, so all the candidates
+// of that color are removed.
+const medusaContradictionPosition = "...4..........6.....3......1............5.7.9....5.7.9.2....78.......789.2....7..1.........2..5...9.2..5...9.2......9.......8......67.9...4..........67.9..3............7.........8..2......9.2.4....9...4.6..9..3......1.............6..9....5........5.......4............8.......7..1.........2...............9..3...........6...........9.2....7...2...67.....45......3.........4.6....2....78.1...5.78.12....7....3......1.........2...67......5...9.....6..9.......8..2....7......5.7.....4..........6.......5...9...45...9.......8..2..........45...9..3......1.....7..1.....7...2...........5.7.91...5.7.9..3..........5.7.91...5.7.9.....6......4............8........8...3......1..4..7.......6......4..7..1..4..7......5.....2...............9"
+
+func TestMedusa(t *testing.T) {
+	var tests = []struct {
+		name       string
+		position   string
+		eliminated string
+	}{
+		{"candidate seeing both colors", medusaTrapPosition, "r1c7-8"},
+		{
+			"color leading to a contradiction", medusaContradictionPosition,
+			"r2c8-6 r3c5-4 r3c5-6 r3c8-9 r5c4-4 r5c6-6 r5c8-5 r6c3-6 r6c4-5 r6c5-9 r6c8-7 r9c5-7",
+		},
+	}
+
+	for _, test := range tests {
+		g, err := ParseSukaku(test.position)
+		if (err != nil) {
+			t.Fatal(err)
+		}
+		var eliminated = eliminations(t, Medusa{}, g)
+		if (eliminated != test.eliminated) {
+			t.Errorf("%s: eliminated %s, want %s", test.name, eliminated, test.eliminated)
+		}
+	}
+}
+
+// remotePairsPosition is the pencil marks of a puzzle at the point
+// where remote pairs is the next step:
+//   ...45..9.1.9...8..........2...1....6..2..5.8...1.6.2..2..9.8....5...6....3..2.6.8
+// The cells holding 3 and 7 chain from r1c9 through r1c7, r8c7, r8c4
+// and r5c4 to r6c6, so r6c9, seeing r1c9 and r6c6, holds neither.
+const remotePairsPosition = ".....6....2..............8....4.........5....1..........3...7..........9..3...7..1...........4.............9.2.........3...7....3...7.........8......6.......5......3.5..........7....3.5.........6..........8.........91...........4......2.........345.7.........8...3.5....1...........4....9.2..........45...9..3.5.7.......6.....3...7.......6....2.........3...7.....4....9....5.......4....9.......8.1..........345.7..........91...............8......6.....3...7...2.........3.5.7....34..7...2.......1.............6...........9..3...7.........8....45......3.5.7....34..7.........8.....5.......4.......3...7..1.............6.....3...7...2...............9........9..3............7......5.....2..........4..........6...1...............8."
+
+func TestRemotePairs(t *testing.T) {
+	position, err := ParseSukaku(remotePairsPosition)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name       string
+		grid       *Grid
+		eliminated string
+	}{
+		{"puzzle position", position, "r6c9-3 r6c9-7"},
+		{
+			"chain of three cells",
+			patternGrid(t, map[Coord][]int{{0, 0}: {1, 2}, {0, 4}: {1, 2}, {4, 4}: {1, 2}}),
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		var eliminated = eliminations(t, RemotePairs{}, test.grid)
+		if (eliminated != test.eliminated) {
+			t.Errorf("%s: eliminated %s, want %s", test.name, eliminated, test.eliminated)
+		}
+	}
+}
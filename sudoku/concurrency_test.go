@@ -0,0 +1,93 @@
+package sudoku
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+// Puzzles with their solution, solved with singles.
+var concurrencyPuzzles = []struct {
+	puzzle   string
+	solution string
+}{
+	{"004209000900000020000367000500000710100080903600903000070000501000001040800000000",
+		"734259186965148327218367495593426718142785963687913254476892531359671842821534679"},
+	{"605000030000007060340200008060001080800030007070600020400008013030100000050000204",
+		"625814739981357462347296158263471985819532647574689321496728513732145896158963274"},
+	{"630000000002605003000908200070000040320070081080000020004309000800504700000000094",
+		"638217459912645873547938216176892345325476981489153627754329168891564732263781594"},
+	{"905200000040090030010006004057000020800109003020000840500400010030010060000007902",
+		"975234681648791235213856794357648129864129573129573846596482317732915468481367952"},
+}
+
+// TestConcurrentSolvers solves the puzzles with a solver per goroutine,
+// many times over. Run it with go test -race to check that independent
+// solvers share no state.
+func TestConcurrentSolvers(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var c = concurrencyPuzzles[i%len(concurrencyPuzzles)]
+			g, err := Parse(c.puzzle)
+			if (err != nil) {
+				t.Error(err)
+				return
+			}
+			g.ListOptions()
+			_, err = NewSolver().Solve(g)
+			if (err != nil) {
+				t.Errorf("%s: %v", c.puzzle, err)
+				return
+			}
+			if (g.String() != c.solution) {
+				t.Errorf("%s: got %s, want %s", c.puzzle, g.String(), c.solution)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// TestConcurrentRender renders grids from several goroutines while the
+// package-wide settings of Render change.
+func TestConcurrentRender(t *testing.T) {
+	var formats = []Format{FormatGrid, FormatHints, FormatOptions, FormatLine}
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			g, err := Parse(concurrencyPuzzles[i%len(concurrencyPuzzles)].puzzle)
+			if (err != nil) {
+				t.Error(err)
+				return
+			}
+			g.ListOptions()
+			for _, format := range formats {
+				var buf bytes.Buffer
+				err := g.Render(&buf, format)
+				if (err != nil) {
+					t.Errorf("format %d: %v", format, err)
+				}
+				if (buf.Len() == 0) {
+					t.Errorf("format %d: nothing rendered", format)
+				}
+			}
+		}(i)
+	}
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			SetStyle(Styles["classic"])
+			if (i%2 == 0) {
+				SetLabels("rc")
+			} else {
+				SetLabels("none")
+			}
+		}(i)
+	}
+	wg.Wait()
+}
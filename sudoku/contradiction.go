@@ -0,0 +1,56 @@
+package sudoku
+
+import (
+	"fmt"
+)
+
+// ContradictionError tells why a grid cannot be solved: an empty cell
+// without options, a value repeated in a house, or a value which has
+// no place left in a house. It wraps ErrNoSolution.
+type ContradictionError struct {
+	House  string  // house of the contradiction, "" for a cell without options
+	Value  int     // value of the contradiction, 0 for a cell without options
+	Cells  []Coord // cells at fault, none when a value has no place left
+	Reason string
+}
+
+func (e *ContradictionError) Error() string {
+	return "no solution, " + e.Reason
+}
+
+func (e *ContradictionError) Unwrap() error {
+	return ErrNoSolution
+}
+
+// contradiction returns why the grid cannot be solved, or nil when no
+// contradiction is found.
+func (g *Grid) contradiction() *ContradictionError {
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (g.cells[row][col] == 0 && g.options[row][col] == 0) {
+				var cell = Coord{row, col}
+				return &ContradictionError{"", 0, []Coord{cell}, fmt.Sprintf("%v has no option left", cell)}
+			}
+		}
+	}
+
+	for _, house := range houses {
+		var placed [10]*Coord
+		var possible digitSet = 0
+		for i, cell := range house.Cells {
+			var value int = g.cells[cell.Row][cell.Col]
+			if (value != 0 && placed[value] != nil) {
+				var cells = []Coord{*placed[value], cell}
+				return &ContradictionError{house.String(), value, cells, fmt.Sprintf("%v holds %d twice, in %v and %v", house, value, cells[0], cells[1])}
+			}
+			placed[value] = &house.Cells[i]
+			possible |= g.options[cell.Row][cell.Col]
+		}
+		for value := 1; value <= 9; value++ {
+			if (placed[value] == nil && !possible.has(value)) {
+				return &ContradictionError{house.String(), value, nil, fmt.Sprintf("%d has no place left in %v", value, house)}
+			}
+		}
+	}
+	return nil
+}
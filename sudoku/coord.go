@@ -0,0 +1,51 @@
+package sudoku
+
+import (
+	"fmt"
+)
+
+// Coord locates a cell in the grid. Rows and columns are numbered from
+// 0 to 8.
+type Coord struct {
+	Row int
+	Col int
+}
+
+// String returns the usual r1c1 notation, numbered from 1.
+func (c Coord) String() string {
+	return fmt.Sprintf("r%dc%d", c.Row+1, c.Col+1)
+}
+
+// Square returns the number of the square holding the cell, from 1 to
+// 9, see getSquareFromRowCol.
+func (c Coord) Square() int {
+	return getSquareFromRowCol(c.Row, c.Col)
+}
+
+// Sees returns true if both cells share a row, column or square.
+func (c Coord) Sees(other Coord) bool {
+	return c != other && (c.Row == other.Row || c.Col == other.Col || c.Square() == other.Square())
+}
+
+// peers holds the 20 peers of each cell, see Peers.
+var peers [9][9][]Coord
+
+func init() {
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			var cell = Coord{row, col}
+			for other := 0; other < 81; other++ {
+				var peer = Coord{other / 9, other % 9}
+				if (cell.Sees(peer)) {
+					peers[row][col] = append(peers[row][col], peer)
+				}
+			}
+		}
+	}
+}
+
+// Peers returns the 20 cells sharing a row, a column or a square with
+// the given cell, ordered row by row.
+func Peers(row int, col int) []Coord {
+	return append([]Coord(nil), peers[row][col]...)
+}
@@ -0,0 +1,23 @@
+package sudoku
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+// DailySeed returns the seed of the puzzle of the day of date, in its
+// location, for namespace: everyone generating with it and the same
+// options gets the same puzzle, and another namespace, such as the
+// name of a club, another puzzle. It is never 0.
+func DailySeed(date time.Time, namespace string) int64 {
+	var hash = fnv.New64a()
+	hash.Write([]byte(date.Format(time.DateOnly)))
+	if (namespace != "") {
+		hash.Write([]byte("/" + namespace))
+	}
+	var seed = int64(hash.Sum64() >> 1)
+	if (seed == 0) {
+		seed = 1
+	}
+	return seed
+}
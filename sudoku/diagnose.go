@@ -0,0 +1,80 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NotUniqueError is returned when the strategies are stuck on a puzzle
+// having several solutions, which no technique can choose between. It
+// wraps ErrNotSolved.
+type NotUniqueError struct {
+	Solutions []Grid // two of the solutions
+}
+
+func (e *NotUniqueError) Error() string {
+	return "could not solve, the puzzle has several solutions"
+}
+
+func (e *NotUniqueError) Unwrap() error {
+	return ErrNotSolved
+}
+
+// TechniquesError is returned when the strategies are stuck on a puzzle
+// having a single solution: it needs techniques beyond the ones the
+// solver was given. It wraps ErrNotSolved.
+type TechniquesError struct {
+	Strategies []string // names of the strategies of the solver
+	EmptyCells int      // empty cells left when the strategies got stuck
+}
+
+func (e *TechniquesError) Error() string {
+	return fmt.Sprintf("could not solve, %d cells are left to techniques beyond %s", e.EmptyCells, strings.Join(e.Strategies, ", "))
+}
+
+func (e *TechniquesError) Unwrap() error {
+	return ErrNotSolved
+}
+
+// diagnose tells why the strategies of s are stuck on g, by searching
+// the solutions left by its options: a *ContradictionError when there
+// is none, a *NotUniqueError when there are several, and a
+// *TechniquesError otherwise.
+func (s *Solver) diagnose(g *Grid) error {
+	var solutions = g.solutionsLeft(2)
+	switch len(solutions) {
+	case 0:
+		return &ContradictionError{"", 0, nil, "no value left in the options completes the grid"}
+	case 1:
+		var names []string
+		for _, strategy := range s.Strategies {
+			names = append(names, strategy.Name())
+		}
+		return &TechniquesError{names, g.CountEmptyCells()}
+	}
+	return &NotUniqueError{solutions}
+}
+
+// solutionsLeft returns the solutions of g left by the options of its
+// empty cells, at most limit of them.
+func (g *Grid) solutionsLeft(limit int) []Grid {
+	var allowed [9][9]digitSet
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			allowed[row][col] = g.options[row][col]
+			if (g.cells[row][col] != 0) {
+				allowed[row][col] = 1 << g.cells[row][col]
+			}
+		}
+	}
+
+	var solutions []Grid
+	dlxSearch(allowed, func(cells [9][9]int) bool {
+		var solution = *g
+		solution.cells = cells
+		solution.options = [9][9]digitSet{}
+		solutions = append(solutions, solution)
+		return len(solutions) < limit
+	})
+	return solutions
+}
@@ -0,0 +1,23 @@
+package sudoku
+
+// CellChange is a cell whose value differs between two grids.
+type CellChange struct {
+	Coord
+	From int // value in the first grid, 0 for empty
+	To   int // value in the second grid, 0 for empty
+}
+
+// Diff returns the cells whose value differs from a to b, row by row.
+// Diffing a puzzle and its solution gives the cells the solver filled;
+// diffing a submitted solution and the expected one gives the mistakes.
+func Diff(a Grid, b Grid) []CellChange {
+	var changes []CellChange
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (a.cells[row][col] != b.cells[row][col]) {
+				changes = append(changes, CellChange{Coord{row, col}, a.cells[row][col], b.cells[row][col]})
+			}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,65 @@
+package sudoku
+
+import (
+	"math/bits"
+)
+
+// digitSet is a set of values from 1 to 9, bit v standing for value v.
+// The options of the cells are digit sets, so that techniques compare
+// and combine them with cheap bitwise operations.
+type digitSet uint16
+
+// allDigits holds the values from 1 to 9.
+const allDigits digitSet = 0x3fe
+
+// digitSetOf returns the set of the given values. Values outside 1 to
+// 9 are ignored, see checkCandidates.
+func digitSetOf(values []int) digitSet {
+	var s digitSet = 0
+	for _, value := range values {
+		if (value >= 1 && value <= 9) {
+			s |= 1 << value
+		}
+	}
+	return s
+}
+
+// has returns true if value is in the set.
+func (s digitSet) has(value int) bool {
+	return s&(1<<value) != 0
+}
+
+// count returns the number of values in the set.
+func (s digitSet) count() int {
+	return bits.OnesCount16(uint16(s))
+}
+
+// first returns the lowest value of the set, 0 when it is empty.
+func (s digitSet) first() int {
+	if (s == 0) {
+		return 0
+	}
+	return bits.TrailingZeros16(uint16(s))
+}
+
+// permute returns the set where each value v is replaced by
+// perm[v-1].
+func (s digitSet) permute(perm [9]int) digitSet {
+	var permuted digitSet = 0
+	for _, value := range s.values() {
+		permuted |= 1 << perm[value-1]
+	}
+	return permuted
+}
+
+// values returns the values of the set in increasing order, nil when
+// it is empty.
+func (s digitSet) values() []int {
+	var values []int
+	for value := 1; value <= 9; value++ {
+		if (s.has(value)) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,215 @@
+package sudoku
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// dimacsVar returns the variable telling that the cell holds value,
+// from 1 to 729.
+func dimacsVar(row int, col int, value int) int {
+	return row*81 + col*9 + value
+}
+
+// ExportDIMACS writes the grid as a boolean formula in conjunctive
+// normal form, in the DIMACS format read by SAT solvers. Variable
+// 81*row + 9*col + value, with row and col from 0, is true when the
+// cell holds value: each cell holds one value, each value is once in
+// each house, and the filled cells are unit clauses. The options of
+// the grid are not used.
+func (g *Grid) ExportDIMACS(w io.Writer) error {
+	var clauses [][]int
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			var cell []int
+			for value := 1; value <= 9; value++ {
+				cell = append(cell, dimacsVar(row, col, value))
+				for other := value + 1; other <= 9; other++ {
+					clauses = append(clauses, []int{-dimacsVar(row, col, value), -dimacsVar(row, col, other)})
+				}
+			}
+			clauses = append(clauses, cell)
+			if (g.cells[row][col] != 0) {
+				clauses = append(clauses, []int{dimacsVar(row, col, g.cells[row][col])})
+			}
+		}
+	}
+	for _, house := range houses {
+		for value := 1; value <= 9; value++ {
+			var somewhere []int
+			for i, a := range house.Cells {
+				somewhere = append(somewhere, dimacsVar(a.Row, a.Col, value))
+				for _, b := range house.Cells[i+1:] {
+					clauses = append(clauses, []int{-dimacsVar(a.Row, a.Col, value), -dimacsVar(b.Row, b.Col, value)})
+				}
+			}
+			clauses = append(clauses, somewhere)
+		}
+	}
+
+	var out = bufio.NewWriter(w)
+	fmt.Fprintf(out, "c sudoku %s\n", g.String())
+	fmt.Fprintf(out, "p cnf %d %d\n", 729, len(clauses))
+	for _, clause := range clauses {
+		for _, literal := range clause {
+			fmt.Fprintf(out, "%d ", literal)
+		}
+		fmt.Fprintln(out, "0")
+	}
+	return out.Flush()
+}
+
+// DecodeDIMACSModel reads the output of a SAT solver run on the
+// formula of ExportDIMACS, in the SAT competition format: a status line
+// "s SATISFIABLE" and the true variables on "v" lines. It returns the
+// cells of the model, or ErrNoSolution when the formula is
+// unsatisfiable.
+func DecodeDIMACSModel(r io.Reader) ([9][9]int, error) {
+	var cells [9][9]int
+	var status string = ""
+	var scanner = bufio.NewScanner(r)
+	for scanner.Scan() {
+		var fields = strings.Fields(scanner.Text())
+		if (len(fields) == 0) {
+			continue
+		}
+		switch fields[0] {
+		case "s":
+			status = strings.Join(fields[1:], " ")
+		case "v":
+			for _, field := range fields[1:] {
+				literal, err := strconv.Atoi(field)
+				if (err != nil) {
+					return cells, fmt.Errorf("sat: invalid literal %q", field)
+				}
+				if (literal < 1 || literal > 729) {
+					continue
+				}
+				var v = literal - 1
+				cells[v/81][v/9%9] = v%9 + 1
+			}
+		}
+	}
+	err := scanner.Err()
+	if (err != nil) {
+		return cells, err
+	}
+
+	switch status {
+	case "SATISFIABLE":
+		return cells, nil
+	case "UNSATISFIABLE":
+		return cells, ErrNoSolution
+	}
+	return cells, errors.New("sat: no status line in the solver output")
+}
+
+// SAT fills the whole grid with an external SAT solver, given the
+// formula of ExportDIMACS. Command is run with the path of the formula
+// as last argument, and must print its model in the SAT competition
+// format, as kissat, cadical or glucose -model do.
+type SAT struct {
+	Command []string // e.g. {"kissat", "-q"}
+}
+
+func (SAT) Name() string {
+	return "sat"
+}
+
+func (s SAT) Apply(g *Grid) (Progress, error) {
+	var progress Progress
+	if (len(s.Command) == 0) {
+		return progress, errors.New("sat: no solver command")
+	}
+
+	file, err := os.CreateTemp("", "sudoku-*.cnf")
+	if (err != nil) {
+		return progress, err
+	}
+	defer os.Remove(file.Name())
+	err = g.ExportDIMACS(file)
+	if (err != nil) {
+		file.Close()
+		return progress, err
+	}
+	err = file.Close()
+	if (err != nil) {
+		return progress, err
+	}
+
+	// SAT solvers exit with 10 when satisfiable and 20 when not, so the
+	// exit status is left to the output
+	var stdout bytes.Buffer
+	var cmd = exec.Command(s.Command[0], append(s.Command[1:], file.Name())...)
+	cmd.Stdout = &stdout
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	if (err != nil && !errors.As(err, &exitErr)) {
+		return progress, fmt.Errorf("sat: %w", err)
+	}
+
+	solution, err := DecodeDIMACSModel(&stdout)
+	if (err != nil) {
+		return progress, err
+	}
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (g.cells[row][col] != 0) {
+				continue
+			}
+			if (solution[row][col] == 0) {
+				return progress, fmt.Errorf("sat: the model leaves %v empty", Coord{row, col})
+			}
+			g.cells[row][col] = solution[row][col]
+			g.options[row][col] = 0
+			progress.add(s.Name(), Placement, row, col, solution[row][col])
+		}
+	}
+	return progress, nil
+}
+
+// readDIMACS reads the puzzle of a formula written by ExportDIMACS: its
+// filled cells are the unit clauses of positive variables. Other
+// clauses are taken as the rules of sudoku, and not checked.
+func readDIMACS(r io.Reader) ([]Puzzle, error) {
+	var cells [9][9]int
+	var header bool = false
+	var scanner = bufio.NewScanner(r)
+	var lineNum int = 0
+	for scanner.Scan() {
+		lineNum++
+		var fields = strings.Fields(scanner.Text())
+		if (len(fields) == 0 || fields[0] == "c") {
+			continue
+		}
+		if (fields[0] == "p") {
+			header = true
+			continue
+		}
+		if (len(fields) != 2 || fields[1] != "0") {
+			continue
+		}
+		variable, err := strconv.Atoi(fields[0])
+		if (err != nil || variable > 729) {
+			return nil, fmt.Errorf("dimacs: line %d: invalid clause %q", lineNum, scanner.Text())
+		}
+		if (variable > 0) {
+			variable--
+			cells[variable/81][variable%81/9] = variable%9 + 1
+		}
+	}
+	if (scanner.Err() != nil) {
+		return nil, scanner.Err()
+	}
+	if (!header) {
+		return nil, errors.New("dimacs: no \"p cnf\" line, not a formula")
+	}
+	return []Puzzle{{Cells: cells}}, nil
+}
@@ -0,0 +1,219 @@
+package sudoku
+
+// DLX fills the whole grid at once with Knuth's Dancing Links, an
+// exact cover search over the 324 constraints of the grid: each cell,
+// and each value in each row, column and square, is covered once. It
+// only reads the cells of the grid, not its options, so it checks the
+// other strategies independently. It is much faster than them for
+// brute-force work, but explains nothing. A puzzle having several
+// solutions is left as it is, with a *NotUniqueError.
+type DLX struct{}
+
+func (DLX) Name() string {
+	return "dlx"
+}
+
+func (d DLX) Apply(g *Grid) (Progress, error) {
+	var progress Progress
+	var solutions = g.AllSolutions(2)
+	if (len(solutions) == 0) {
+		return progress, ErrNoSolution
+	}
+	if (len(solutions) > 1) {
+		return progress, &NotUniqueError{solutions}
+	}
+	var solution = solutions[0].cells
+
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (g.cells[row][col] == 0) {
+				g.cells[row][col] = solution[row][col]
+				g.options[row][col] = 0
+				progress.add(d.Name(), Placement, row, col, solution[row][col])
+			}
+		}
+	}
+	return progress, nil
+}
+
+// CountSolutions returns the number of solutions of the grid, counting
+// no further than limit: a limit of 2 tells whether the puzzle has a
+// single solution. Only the cells are read, with Dancing Links.
+func (g *Grid) CountSolutions(limit int) int {
+	if (limit <= 0) {
+		return 0
+	}
+	return countSolutions(g.cells, limit)
+}
+
+// countSolutions returns the number of solutions of the cells, counting
+// no further than limit.
+func countSolutions(cells [9][9]int, limit int) int {
+	var count int = 0
+	dlxSearch(givenSets(cells), func([9][9]int) bool {
+		count++
+		return count < limit
+	})
+	return count
+}
+
+// AllSolutions returns the solutions of the grid, at most max of them,
+// in no particular order. The solutions keep the givens of the grid.
+func (g *Grid) AllSolutions(max int) []Grid {
+	var solutions []Grid
+	if (max <= 0) {
+		return solutions
+	}
+	dlxSearch(givenSets(g.cells), func(cells [9][9]int) bool {
+		var solution = *g
+		solution.cells = cells
+		solution.options = [9][9]digitSet{}
+		solutions = append(solutions, solution)
+		return len(solutions) < max
+	})
+	return solutions
+}
+
+// dancingLinks is the sparse matrix of the exact cover problem. Nodes
+// are indexes in the slices: 0 is the root, 1 to 324 the column
+// headers, then come the nodes of the candidate rows.
+type dancingLinks struct {
+	left, right, up, down []int
+	column                []int // header of the column of each node
+	candidate             []int // row*81 + col*9 + value-1 of each node
+	size                  []int // number of nodes in each column
+}
+
+// dlxColumns is the number of constraints of a grid.
+const dlxColumns = 4 * 81
+
+// newDancingLinks builds the matrix of the candidates of the grid, the
+// values allowed in each cell.
+func newDancingLinks(allowed [9][9]digitSet) *dancingLinks {
+	var d = &dancingLinks{size: make([]int, dlxColumns+1)}
+	for i := 0; i <= dlxColumns; i++ {
+		d.left = append(d.left, (i+dlxColumns)%(dlxColumns+1))
+		d.right = append(d.right, (i+1)%(dlxColumns+1))
+		d.up = append(d.up, i)
+		d.down = append(d.down, i)
+		d.column = append(d.column, i)
+		d.candidate = append(d.candidate, -1)
+	}
+
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			for _, value := range allowed[row][col].values() {
+				var square = (row/3)*3 + col/3
+				var columns = [4]int{
+					1 + row*9 + col,
+					1 + 81 + row*9 + value - 1,
+					1 + 162 + col*9 + value - 1,
+					1 + 243 + square*9 + value - 1,
+				}
+				var first = len(d.left)
+				for i, header := range columns {
+					var node = first + i
+					d.left = append(d.left, first+(i+3)%4)
+					d.right = append(d.right, first+(i+1)%4)
+					d.up = append(d.up, d.up[header])
+					d.down = append(d.down, header)
+					d.down[d.up[header]] = node
+					d.up[header] = node
+					d.column = append(d.column, header)
+					d.candidate = append(d.candidate, row*81+col*9+value-1)
+					d.size[header]++
+				}
+			}
+		}
+	}
+	return d
+}
+
+// cover removes column c from the header list, and the rows having a
+// node in c from the other columns.
+func (d *dancingLinks) cover(c int) {
+	d.right[d.left[c]] = d.right[c]
+	d.left[d.right[c]] = d.left[c]
+	for i := d.down[c]; i != c; i = d.down[i] {
+		for j := d.right[i]; j != i; j = d.right[j] {
+			d.down[d.up[j]] = d.down[j]
+			d.up[d.down[j]] = d.up[j]
+			d.size[d.column[j]]--
+		}
+	}
+}
+
+// uncover restores column c, undoing cover.
+func (d *dancingLinks) uncover(c int) {
+	for i := d.up[c]; i != c; i = d.up[i] {
+		for j := d.left[i]; j != i; j = d.left[j] {
+			d.size[d.column[j]]++
+			d.down[d.up[j]] = j
+			d.up[d.down[j]] = j
+		}
+	}
+	d.right[d.left[c]] = c
+	d.left[d.right[c]] = c
+}
+
+// search covers the remaining columns, calling visit with the cells of
+// each solution found, and returns false as soon as visit does.
+func (d *dancingLinks) search(chosen []int, visit func([9][9]int) bool) bool {
+	if (d.right[0] == 0) {
+		var cells [9][9]int
+		for _, node := range chosen {
+			var candidate = d.candidate[node]
+			cells[candidate/81][candidate/9%9] = candidate%9 + 1
+		}
+		return visit(cells)
+	}
+
+	// the column with the fewest rows left
+	var c = d.right[0]
+	for i := d.right[c]; i != 0; i = d.right[i] {
+		if (d.size[i] < d.size[c]) {
+			c = i
+		}
+	}
+	if (d.size[c] == 0) {
+		return true
+	}
+
+	d.cover(c)
+	for i := d.down[c]; i != c; i = d.down[i] {
+		for j := d.right[i]; j != i; j = d.right[j] {
+			d.cover(d.column[j])
+		}
+		var more = d.search(append(chosen, i), visit)
+		for j := d.left[i]; j != i; j = d.left[j] {
+			d.uncover(d.column[j])
+		}
+		if (!more) {
+			d.uncover(c)
+			return false
+		}
+	}
+	d.uncover(c)
+	return true
+}
+
+// dlxSearch calls visit with each solution allowing the given values
+// in each cell, until visit returns false.
+func dlxSearch(allowed [9][9]digitSet, visit func([9][9]int) bool) {
+	newDancingLinks(allowed).search(make([]int, 0, 81), visit)
+}
+
+// givenSets returns the values allowed by the cells alone: its value
+// for a filled cell, any value for an empty one.
+func givenSets(cells [9][9]int) [9][9]digitSet {
+	var allowed [9][9]digitSet
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			allowed[row][col] = allDigits
+			if (cells[row][col] != 0) {
+				allowed[row][col] = 1 << cells[row][col]
+			}
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,83 @@
+package sudoku
+
+import (
+	"errors"
+	"testing"
+)
+
+// twoSolutionsPuzzle is the first of the concurrencyPuzzles solved but
+// for r1c3, r1c5, r2c3 and r2c5, which can hold 4 and 5 either way.
+const twoSolutionsPuzzle = "730209186960108327218367495593426718142785963687913254476892531359671842821534679"
+
+func TestAllSolutions(t *testing.T) {
+	var tests = []struct {
+		name      string
+		puzzle    string
+		max       int
+		count     int
+		solutions []string
+	}{
+		{"single solution", concurrencyPuzzles[0].puzzle, 10, 1, []string{concurrencyPuzzles[0].solution}},
+		{"two solutions", twoSolutionsPuzzle, 10, 2, []string{
+			"734259186965148327218367495593426718142785963687913254476892531359671842821534679",
+			"735249186964158327218367495593426718142785963687913254476892531359671842821534679",
+		}},
+		{"two solutions, at most one", twoSolutionsPuzzle, 1, 2, nil},
+		{"no solution", "11" + concurrencyPuzzles[0].puzzle[2:], 10, 0, nil},
+	}
+
+	for _, test := range tests {
+		g, err := Parse(test.puzzle)
+		if (err != nil) {
+			t.Fatal(err)
+		}
+		var found = make(map[string]bool)
+		for _, solution := range g.AllSolutions(test.max) {
+			found[solution.String()] = true
+		}
+		if (len(found) != min(test.count, test.max)) {
+			t.Errorf("%s: got %d solutions, want %d", test.name, len(found), min(test.count, test.max))
+		}
+		for _, solution := range test.solutions {
+			if (!found[solution]) {
+				t.Errorf("%s: %s not found", test.name, solution)
+			}
+		}
+		if (g.CountSolutions(10) != test.count) {
+			t.Errorf("%s: counted %d solutions, want %d", test.name, g.CountSolutions(10), test.count)
+		}
+	}
+}
+
+func TestDLX(t *testing.T) {
+	g, err := Parse(concurrencyPuzzles[0].puzzle)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	_, err = DLX{}.Apply(g)
+	if (err != nil || g.String() != concurrencyPuzzles[0].solution) {
+		t.Errorf("single solution: got %s, %v", g, err)
+	}
+
+	g, err = Parse(twoSolutionsPuzzle)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	progress, err := DLX{}.Apply(g)
+	var notUnique *NotUniqueError
+	if (!errors.As(err, &notUnique) || len(notUnique.Solutions) != 2) {
+		t.Errorf("two solutions: got error %v, want a *NotUniqueError with both", err)
+	}
+	if (len(progress.Steps) != 0 || g.String() != twoSolutionsPuzzle) {
+		t.Errorf("two solutions: filled %d cells", len(progress.Steps))
+	}
+
+	g, err = Parse("11" + concurrencyPuzzles[0].puzzle[2:])
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	_, err = DLX{}.Apply(g)
+	if (!errors.Is(err, ErrNoSolution)) {
+		t.Errorf("no solution: got error %v, want %v", err, ErrNoSolution)
+	}
+}
@@ -0,0 +1,100 @@
+package sudoku
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrInvalidCode is returned when decoding a string not made by Encode.
+var ErrInvalidCode = errors.New("not a valid puzzle code")
+
+// Encode returns a short URL-safe code of the grid values, to share
+// puzzles in links. The code packs one bit per cell telling whether it
+// is filled, then 4 bits per filled cell for its value, in base64: a
+// puzzle of 30 clues takes 34 characters, a full grid 68.
+func (g *Grid) Encode() string {
+	var bits bitBuffer
+	var values []int
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			bits.append(min(g.cells[row][col], 1), 1)
+			if (g.cells[row][col] != 0) {
+				values = append(values, g.cells[row][col])
+			}
+		}
+	}
+	for _, value := range values {
+		bits.append(value-1, 4)
+	}
+
+	var data = make([]byte, (len(bits)+7)/8)
+	for i, bit := range bits {
+		if (bit) {
+			data[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode returns the grid of a code made by Encode.
+func Decode(code string) (*Grid, error) {
+	data, err := base64.RawURLEncoding.DecodeString(code)
+	if (err != nil || len(data) < 11) {
+		return nil, ErrInvalidCode
+	}
+	var bit = func(i int) int {
+		return int(data[i/8]>>(7-i%8)) & 1
+	}
+
+	var filled int = 0
+	for i := 0; i < 81; i++ {
+		filled += bit(i)
+	}
+	if (len(data) != (81+4*filled+7)/8) {
+		return nil, fmt.Errorf("%w, expected %d bytes for %d clues, got %d", ErrInvalidCode, (81+4*filled+7)/8, filled, len(data))
+	}
+
+	var cells [9][9]int
+	var pos int = 81
+	for i := 0; i < 81; i++ {
+		if (bit(i) == 0) {
+			continue
+		}
+		var value int = 0
+		for j := 0; j < 4; j++ {
+			value = value<<1 | bit(pos+j)
+		}
+		pos += 4
+		if (value > 8) {
+			return nil, fmt.Errorf("%w, r%dc%d holds %d", ErrInvalidCode, i/9+1, i%9+1, value+1)
+		}
+		cells[i/9][i%9] = value + 1
+	}
+	return NewGrid(cells), nil
+}
+
+// readCodes reads one code made by Encode per non-empty line. Lines
+// starting with # are comments.
+func readCodes(r io.Reader) ([]Puzzle, error) {
+	var puzzles []Puzzle
+	var scanner = bufio.NewScanner(r)
+	var lineNum int = 0
+	for scanner.Scan() {
+		lineNum++
+		var line = strings.TrimSpace(scanner.Text())
+		if (line == "" || strings.HasPrefix(line, "#")) {
+			continue
+		}
+
+		g, err := Decode(line)
+		if (err != nil) {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		puzzles = append(puzzles, Puzzle{Cells: g.cells})
+	}
+	return puzzles, scanner.Err()
+}
@@ -0,0 +1,66 @@
+package sudoku
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	var tests = []struct {
+		name   string
+		puzzle string
+		length int
+	}{
+		{"empty", strings.Repeat("0", 81), 15},
+		{"puzzle", "004209000900000020000367000500000710100080903600903000070000501000001040800000000", 31},
+		{"solution", "734259186965148327218367495593426718142785963687913254476892531359671842821534679", 68},
+	}
+	for _, test := range tests {
+		cells, err := ParseCells(test.puzzle)
+		if (err != nil) {
+			t.Fatal(err)
+		}
+		var code = NewGrid(cells).Encode()
+		if (len(code) != test.length) {
+			t.Errorf("%s: encoded in %d characters, want %d", test.name, len(code), test.length)
+		}
+		g, err := Decode(code)
+		if (err != nil) {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if (g.Cells() != cells) {
+			t.Errorf("%s: decoded %s", test.name, CellsString(g.Cells(), '0'))
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	// r1c1 filled with 16, past 9
+	var tooHigh = make([]byte, 11)
+	tooHigh[0] = 0x80
+	tooHigh[10] = 0x78
+
+	var tests = []struct {
+		name string
+		code string
+	}{
+		{"not base64", "a puzzle"},
+		{"too short", "AAAA"},
+		{"missing values", base64.RawURLEncoding.EncodeToString(append([]byte{0xff}, make([]byte, 10)...))},
+		{"value too high", base64.RawURLEncoding.EncodeToString(tooHigh)},
+	}
+	for _, test := range tests {
+		_, err := Decode(test.code)
+		if (!errors.Is(err, ErrInvalidCode)) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, ErrInvalidCode)
+		}
+	}
+
+	_, err := ReadPuzzles(strings.NewReader("# codes\n\nAAAA\n"), "code")
+	if (err == nil || !strings.Contains(err.Error(), "line 3")) {
+		t.Errorf("got error %v, want one on line 3", err)
+	}
+}
@@ -0,0 +1,68 @@
+package sudoku
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Event is a deduction the grid reports as it is made: a value which
+// can be placed in a cell, or removed from its options.
+type Event struct {
+	Kind      StepKind `json:"kind"`
+	Technique string   `json:"technique"`
+	House     string   `json:"house,omitempty"` // e.g. "row 2", empty when no house is involved
+	Cell      Coord    `json:"cell"`
+	Digit     int      `json:"digit"`
+	Reason    string   `json:"reason,omitempty"` // why the deduction holds, for chains and colorings
+}
+
+// String returns the explanation printed in Verbose mode.
+func (e Event) String() string {
+	if (e.Kind == Elimination && e.Reason != "") {
+		return fmt.Sprintf("%s removes %d from %v: %s", e.Technique, e.Digit, e.Cell, e.Reason)
+	}
+	if (e.Kind == Elimination && e.House == "") {
+		return fmt.Sprintf("%s removes %d from %v", e.Technique, e.Digit, e.Cell)
+	}
+	if (e.Kind == Elimination) {
+		return fmt.Sprintf("In %s, %s removes %d from %v", e.House, e.Technique, e.Digit, e.Cell)
+	}
+	if (e.House != "") {
+		return fmt.Sprintf("In %s, value %d can only be in one place", e.House, e.Digit)
+	}
+	return fmt.Sprintf("r%d,c%d: %s", e.Cell.Row+1, e.Cell.Col+1, paint(colorRed, fmt.Sprint([]int{e.Digit})))
+}
+
+// emit reports a deduction to OnEvent, or prints it in Verbose mode.
+func (g *Grid) emit(e Event) {
+	if (g.OnEvent != nil) {
+		g.OnEvent(e)
+	} else if (g.Verbose) {
+		fmt.Println(e)
+	}
+}
+
+// JSONLines returns a function writing each event it receives to w as
+// a JSON object on its own line, for use as Grid.OnEvent:
+//   {"kind":"placement","technique":"hidden-single","house":"row 2","cell":"r2c5","digit":1}
+// Write errors are ignored, the solve log being only informative.
+func JSONLines(w io.Writer) func(Event) {
+	var encoder = json.NewEncoder(w)
+	return func(e Event) {
+		encoder.Encode(e)
+	}
+}
+
+// MarshalText encodes the kind as "placement" or "elimination".
+func (k StepKind) MarshalText() ([]byte, error) {
+	if (k == Elimination) {
+		return []byte("elimination"), nil
+	}
+	return []byte("placement"), nil
+}
+
+// MarshalText encodes the cell in the r1c1 notation.
+func (c Coord) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
@@ -0,0 +1,105 @@
+package sudoku
+
+import (
+	"math/bits"
+)
+
+// XWing removes a value from two columns when, in two rows, the value
+// can only go in these columns: whichever way it is placed, both
+// columns get it in these rows. Rows and columns also work the other
+// way around.
+type XWing struct{}
+
+func (XWing) Name() string {
+	return "x-wing"
+}
+
+func (x XWing) Apply(g *Grid) (Progress, error) {
+	return g.applyFish(x.Name(), 2), nil
+}
+
+// Swordfish is XWing over three rows and three columns.
+type Swordfish struct{}
+
+func (Swordfish) Name() string {
+	return "swordfish"
+}
+
+func (s Swordfish) Apply(g *Grid) (Progress, error) {
+	return g.applyFish(s.Name(), 3), nil
+}
+
+// Jellyfish is XWing over four rows and four columns. Its search is
+// the most expensive of the fish and it is rarely needed, so it is
+// not one of the DefaultStrategies: select it by name, or with
+// --jellyfish on the command line.
+type Jellyfish struct{}
+
+func (Jellyfish) Name() string {
+	return "jellyfish"
+}
+
+func (j Jellyfish) Apply(g *Grid) (Progress, error) {
+	return g.applyFish(j.Name(), 4), nil
+}
+
+// applyFish looks for fish patterns of size base lines: for a value,
+// size rows (or columns) whose places for it all fall in size columns
+// (or rows), from which the value is then removed outside the base
+// lines.
+func (g *Grid) applyFish(technique string, size int) Progress {
+	var progress Progress
+	for value := 1; value <= 9; value++ {
+		for _, lines := range [][]House{houses[9:18], houses[18:]} {
+			// places[i] is a bit mask of the positions of value in lines[i]
+			var places [9]int
+			var candidates []int
+			for i, line := range lines {
+				for j, cell := range line.Cells {
+					if (g.HasCandidate(cell.Row, cell.Col, value)) {
+						places[i] |= 1 << j
+					}
+				}
+				var count = bits.OnesCount(uint(places[i]))
+				if (count >= 2 && count <= size) {
+					candidates = append(candidates, i)
+				}
+			}
+
+			var search func(start int, base []int, cover int)
+			search = func(start int, base []int, cover int) {
+				if (bits.OnesCount(uint(cover)) > size) {
+					return
+				}
+				if (len(base) == size) {
+					g.eliminateFish(technique, value, lines, base, cover, &progress)
+					return
+				}
+				for k := start; k < len(candidates); k++ {
+					search(k+1, append(base, candidates[k]), cover|places[candidates[k]])
+				}
+			}
+			search(0, nil, 0)
+		}
+	}
+	return progress
+}
+
+// eliminateFish removes value from the cover positions of the lines
+// which are not part of the base.
+func (g *Grid) eliminateFish(technique string, value int, lines []House, base []int, cover int, progress *Progress) {
+	var isBase [9]bool
+	for _, i := range base {
+		isBase[i] = true
+	}
+	for i, line := range lines {
+		if (isBase[i]) {
+			continue
+		}
+		for j, cell := range line.Cells {
+			if (cover&(1<<j) != 0) {
+				g.eliminate(progress, technique, cell, value, line.String(), "")
+			}
+		}
+	}
+}
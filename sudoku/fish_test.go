@@ -0,0 +1,99 @@
+package sudoku
+
+import (
+	"testing"
+)
+
+// fishTest is a value whose places make a fish, or not, and the
+// eliminations expected from it.
+type fishTest struct {
+	name       string
+	value      int
+	picture    string
+	eliminated string
+}
+
+// testFish checks the eliminations of strategy on each test.
+func testFish(t *testing.T, strategy Strategy, tests []fishTest) {
+	for _, test := range tests {
+		var eliminated = eliminations(t, strategy, valueGrid(t, test.value, test.picture))
+		if (eliminated != test.eliminated) {
+			t.Errorf("%s: eliminated %s, want %s", test.name, eliminated, test.eliminated)
+		}
+	}
+}
+
+func TestXWing(t *testing.T) {
+	testFish(t, XWing{}, []fishTest{
+		{
+			"rows", 1, `
+				xx.xxxx.x
+				..x....x.
+				xxxxxxxxx
+				xx.xxxx.x
+				xx.xxxx.x
+				..x....x.
+				xx.xxxxxx
+				xx.xxxx.x
+				xx.xxxx.x`,
+			"r3c3-1 r3c8-1 r7c8-1",
+		},
+		{
+			"columns", 8, `
+				x.xxxx.xx
+				x.xxxx.xx
+				x.xxxx.xx
+				xx.x..x..
+				x.xxxx.xx
+				x.xxxx.xx
+				x.xxxx.xx
+				x.xxxx.xx
+				.x...xx..`,
+			"r4c1-8 r4c4-8 r9c6-8",
+		},
+		{
+			"three rows over three columns", 2, swordfishPicture,
+			"",
+		},
+	})
+}
+
+// swordfishPicture has three rows holding a value in the same three
+// columns, where r3c1 and r7c3 also hold it.
+const swordfishPicture = `
+	xx.......
+	...xxxxxx
+	x..xxxxxx
+	...xxxxxx
+	.xx......
+	...xxxxxx
+	..xxxxxxx
+	...xxxxxx
+	x.x......`
+
+func TestSwordfish(t *testing.T) {
+	testFish(t, Swordfish{}, []fishTest{
+		{"rows", 2, swordfishPicture, "r3c1-2 r7c3-2"},
+		{"four rows over four columns", 6, jellyfishPicture, ""},
+	})
+}
+
+// jellyfishPicture has four rows holding a value in the same four
+// columns, where r9c1 and r9c4 also hold it.
+const jellyfishPicture = `
+	xx.......
+	....xxxxx
+	.xx......
+	....xxxxx
+	..xx.....
+	....xxxxx
+	x..x.....
+	....xxxxx
+	x..xxxxxx`
+
+func TestJellyfish(t *testing.T) {
+	testFish(t, Jellyfish{}, []fishTest{
+		{"rows", 6, jellyfishPicture, "r9c1-6 r9c4-6"},
+		{"three rows over three columns", 2, swordfishPicture, ""},
+	})
+}
@@ -0,0 +1,614 @@
+package sudoku
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Puzzle is a grid read from or written to a puzzle file, along with
+// the metadata some formats carry.
+type Puzzle struct {
+	Cells       [9][9]int
+	Title       string
+	Author      string
+	Description string
+	Comment     string
+	Source      string
+	Difficulty  string
+	Date        string
+	URL         string
+
+	// Candidates are the options of the cells known beforehand, nil
+	// when the format does not carry them.
+	Candidates *[9][9][]int
+
+	// Solution is the solved grid, all zeros when unknown.
+	Solution [9][9]int
+
+	// Technique is the technique the puzzle illustrates, and Expected
+	// the deductions it leads to, as given by technique libraries.
+	Technique string
+	Expected  []Step
+
+	// Seed is the seed sudoksolv generated the puzzle from, nil when
+	// unknown. Generating with the same seed and options makes the same
+	// puzzle again.
+	Seed *int64
+}
+
+// CheckSolution returns an error when solved, the grid solving p led
+// to, disagrees with what p tells of its solution: a cell differing
+// from Solution, or a deduction of Expected it contradicts. The cells
+// solved leaves empty are not checked.
+func (p Puzzle) CheckSolution(solved [9][9]int) error {
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			var value, want = solved[row][col], p.Solution[row][col]
+			if (value != 0 && want != 0 && value != want) {
+				return fmt.Errorf("%v is %d, the solution has %d", Coord{row, col}, value, want)
+			}
+		}
+	}
+	for _, step := range p.Expected {
+		var value = solved[step.Row][step.Col]
+		if (value == 0) {
+			continue
+		}
+		if ((step.Kind == Placement && value != step.Value) || (step.Kind == Elimination && value == step.Value)) {
+			return fmt.Errorf("%v is %d, against the expected deduction %v", Coord{step.Row, step.Col}, value, step)
+		}
+	}
+	return nil
+}
+
+// Formats lists the supported puzzle file formats:
+//   line: one puzzle per line, 81 digits, 0 for empty cells
+//   sdm:  SadMan Sudoku collection, one puzzle per line
+//   sdk:  SadMan Sudoku single puzzle, #-headers then 9 lines of 9 cells
+//   json: an object (or array of objects) with grid, candidates,
+//         solution and metadata
+//   jsonl: one json object per line
+//   csv:  nine records of nine values per puzzle, empty cells blank or 0
+//   hodoku: HoDoKu technique library, one annotated puzzle per line
+//   qr:   PNG image of a QR code of the 81 digits, one puzzle
+//   code: one short code per line, see Grid.Encode
+//   pdf:  printable sheets, two puzzles per page, see WritePDF
+//         (write only)
+//   latex: environments of the LaTeX sudoku package
+//   dimacs: formula for SAT solvers, one puzzle, see ExportDIMACS
+var Formats = []string{"line", "sdm", "sdk", "json", "jsonl", "csv", "hodoku", "qr", "code", "pdf", "latex", "dimacs"}
+
+// FormatFromPath guesses the format of a puzzle file from its extension.
+func FormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".sdm":
+		return "sdm"
+	case ".sdk":
+		return "sdk"
+	case ".json":
+		return "json"
+	case ".jsonl":
+		return "jsonl"
+	case ".csv":
+		return "csv"
+	case ".png", ".gif", ".jpg", ".jpeg":
+		return "qr"
+	case ".pdf":
+		return "pdf"
+	case ".tex":
+		return "latex"
+	case ".cnf":
+		return "dimacs"
+	}
+	return "line"
+}
+
+// ParseCells converts 81 characters to grid cells. Digits 1 to 9 are
+// clues, and 0, . or _ mark an empty cell.
+func ParseCells(str string) ([9][9]int, error) {
+	var cells [9][9]int
+	if (len(str) != 81) {
+		return cells, fmt.Errorf("%w, got %d", ErrInvalidLength, len(str))
+	}
+
+	for i, ch := range str {
+		switch {
+		case ch == '0' || ch == '.' || ch == '_':
+			cells[i/9][i%9] = 0
+		case ch >= '1' && ch <= '9':
+			cells[i/9][i%9] = int(ch - '0')
+		default:
+			return cells, fmt.Errorf("%w, got %q at position %d", ErrInvalidChar, ch, i+1)
+		}
+	}
+	return cells, nil
+}
+
+// CellsString converts grid cells to 81 characters, using empty for
+// empty cells.
+func CellsString(cells [9][9]int, empty byte) string {
+	var str []byte
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (cells[row][col] == 0) {
+				str = append(str, empty)
+			} else {
+				str = append(str, byte('0'+cells[row][col]))
+			}
+		}
+	}
+	return string(str)
+}
+
+// ReadPuzzles reads all puzzles of the given format from r.
+func ReadPuzzles(r io.Reader, format string) ([]Puzzle, error) {
+	switch format {
+	case "line", "sdm":
+		return readLines(r)
+	case "sdk":
+		return readSdk(r)
+	case "json":
+		return readJSON(r)
+	case "jsonl":
+		return readJSONLines(r)
+	case "csv":
+		return readCSV(r)
+	case "hodoku":
+		return readHodoku(r)
+	case "qr":
+		return readQRPuzzles(r)
+	case "code":
+		return readCodes(r)
+	case "latex":
+		return readLaTeX(r)
+	case "dimacs":
+		return readDIMACS(r)
+	case "pdf":
+		return nil, errors.New("pdf: reading printed puzzles is not supported")
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+// WritePuzzles writes puzzles to w in the given format.
+func WritePuzzles(w io.Writer, format string, puzzles []Puzzle) error {
+	switch format {
+	case "line":
+		return writeLines(w, puzzles, '0')
+	case "sdm":
+		return writeLines(w, puzzles, '.')
+	case "sdk":
+		return writeSdk(w, puzzles)
+	case "json":
+		return writeJSON(w, puzzles)
+	case "jsonl":
+		return writeJSONLines(w, puzzles)
+	case "csv":
+		return writeCSV(w, puzzles)
+	case "hodoku":
+		return writeHodoku(w, puzzles)
+	case "qr":
+		return writeQRPuzzles(w, puzzles)
+	case "pdf":
+		return WritePDF(w, puzzles, PDFOptions{PerPage: 2})
+	case "latex":
+		for i, p := range puzzles {
+			if (i > 0) {
+				fmt.Fprintln(w)
+			}
+			err := NewGrid(p.Cells).Render(w, FormatLaTeX)
+			if (err != nil) {
+				return err
+			}
+		}
+		return nil
+	case "dimacs":
+		if (len(puzzles) != 1) {
+			return fmt.Errorf("dimacs: a formula holds one puzzle, got %d", len(puzzles))
+		}
+		return NewGrid(puzzles[0].Cells).ExportDIMACS(w)
+	case "code":
+		for _, p := range puzzles {
+			_, err := fmt.Fprintln(w, NewGrid(p.Cells).Encode())
+			if (err != nil) {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// readLines reads one puzzle per non-empty line. Lines starting with #
+// are comments.
+func readLines(r io.Reader) ([]Puzzle, error) {
+	var puzzles []Puzzle
+	var scanner = bufio.NewScanner(r)
+	var lineNum int = 0
+	for scanner.Scan() {
+		lineNum++
+		var line = strings.TrimSpace(scanner.Text())
+		if (line == "" || strings.HasPrefix(line, "#")) {
+			continue
+		}
+
+		cells, err := ParseCells(line)
+		if (err != nil) {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		puzzles = append(puzzles, Puzzle{Cells: cells})
+	}
+	return puzzles, scanner.Err()
+}
+
+// readLaTeX reads the sudoku environments of the LaTeX sudoku package,
+// as written by FormatLaTeX: nine rows of cells between |, ending with
+// a period, blank cells being empty. Text around them is skipped.
+func readLaTeX(r io.Reader) ([]Puzzle, error) {
+	var puzzles []Puzzle
+	var scanner = bufio.NewScanner(r)
+	var lineNum int = 0
+	var rows []string // rows of the environment being read, nil outside
+	for scanner.Scan() {
+		lineNum++
+		var line = strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "\\begin{sudoku}"):
+			rows = []string{}
+		case rows == nil:
+		case strings.HasPrefix(line, "\\end{sudoku}"):
+			if (len(rows) != 9) {
+				return nil, fmt.Errorf("latex: line %d: expected 9 rows, got %d", lineNum, len(rows))
+			}
+			cells, err := ParseCells(strings.Join(rows, ""))
+			if (err != nil) {
+				return nil, fmt.Errorf("latex: line %d: %w", lineNum, err)
+			}
+			puzzles = append(puzzles, Puzzle{Cells: cells})
+			rows = nil
+		case line != "":
+			var fields = strings.Split(strings.TrimSuffix(line, "."), "|")
+			if (len(fields) != 11) {
+				return nil, fmt.Errorf("latex: line %d: expected 9 cells between |, got %q", lineNum, line)
+			}
+			var row string
+			for _, field := range fields[1:10] {
+				field = strings.TrimSpace(field)
+				if (field == "") {
+					field = "0"
+				}
+				row += field
+			}
+			rows = append(rows, row)
+		}
+	}
+	return puzzles, scanner.Err()
+}
+
+func writeLines(w io.Writer, puzzles []Puzzle, empty byte) error {
+	for _, p := range puzzles {
+		_, err := fmt.Fprintln(w, CellsString(p.Cells, empty))
+		if (err != nil) {
+			return err
+		}
+	}
+	return nil
+}
+
+// readSdk reads a SadMan Sudoku file. Headers #A (author),
+// #D (description), #C (comment), #B (date), #S (source), #L (level)
+// and #U (URL) come first, followed by nine lines of nine cells.
+// Files saved with a [Puzzle] section are read up to the next section,
+// such as the [State] of a game in progress.
+func readSdk(r io.Reader) ([]Puzzle, error) {
+	var p Puzzle
+	var rows []string
+	var scanner = bufio.NewScanner(r)
+	for scanner.Scan() {
+		var line = strings.TrimSpace(scanner.Text())
+		if (line == "" || line == "[Puzzle]") {
+			continue
+		}
+		if (strings.HasPrefix(line, "[")) {
+			break
+		}
+
+		if (strings.HasPrefix(line, "#")) {
+			if (len(line) < 2) {
+				continue
+			}
+			var value = strings.TrimSpace(line[2:])
+			switch line[1] {
+			case 'A':
+				p.Author = value
+			case 'D':
+				p.Description = value
+			case 'C':
+				p.Comment = value
+			case 'B':
+				p.Date = value
+			case 'S':
+				p.Source = value
+			case 'L':
+				p.Difficulty = value
+			case 'U':
+				p.URL = value
+			}
+			continue
+		}
+
+		if (len(line) != 9) {
+			return nil, fmt.Errorf("sdk: row %d has %d cells instead of 9", len(rows)+1, len(line))
+		}
+		rows = append(rows, line)
+	}
+	if (scanner.Err() != nil) {
+		return nil, scanner.Err()
+	}
+
+	if (len(rows) != 9) {
+		return nil, fmt.Errorf("sdk: %d rows instead of 9", len(rows))
+	}
+	cells, err := ParseCells(strings.Join(rows, ""))
+	if (err != nil) {
+		return nil, fmt.Errorf("sdk: %w", err)
+	}
+	p.Cells = cells
+	return []Puzzle{p}, nil
+}
+
+func writeSdk(w io.Writer, puzzles []Puzzle) error {
+	if (len(puzzles) != 1) {
+		return fmt.Errorf("sdk: holds a single puzzle, got %d", len(puzzles))
+	}
+
+	var p = puzzles[0]
+	var headers = []struct {
+		tag   byte
+		value string
+	}{
+		{'A', p.Author},
+		{'D', p.Description},
+		{'C', p.Comment},
+		{'B', p.Date},
+		{'S', p.Source},
+		{'L', p.Difficulty},
+		{'U', p.URL},
+	}
+	for _, header := range headers {
+		if (header.value != "") {
+			fmt.Fprintf(w, "#%c %s\n", header.tag, header.value)
+		}
+	}
+
+	var str = CellsString(p.Cells, '.')
+	for row := 0; row < 9; row++ {
+		_, err := fmt.Fprintln(w, str[row*9:row*9+9])
+		if (err != nil) {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonPuzzle is the JSON representation of a puzzle.
+type jsonPuzzle struct {
+	Grid        string       `json:"grid"`
+	Candidates  *[9][9][]int `json:"candidates,omitempty"`
+	Solution    string       `json:"solution,omitempty"`
+	Title       string       `json:"title,omitempty"`
+	Technique   string       `json:"technique,omitempty"`
+	Author      string       `json:"author,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Comment     string       `json:"comment,omitempty"`
+	Source      string       `json:"source,omitempty"`
+	Difficulty  string       `json:"difficulty,omitempty"`
+	Date        string       `json:"date,omitempty"`
+	URL         string       `json:"url,omitempty"`
+	Seed        *int64       `json:"seed,omitempty"`
+}
+
+// newJSONPuzzle returns the JSON representation of p.
+func newJSONPuzzle(p Puzzle) jsonPuzzle {
+	var solution string
+	if (p.Solution != [9][9]int{}) {
+		solution = CellsString(p.Solution, '0')
+	}
+	return jsonPuzzle{
+		Grid:        CellsString(p.Cells, '0'),
+		Candidates:  jsonCandidates(p.Candidates),
+		Solution:    solution,
+		Title:       p.Title,
+		Technique:   p.Technique,
+		Author:      p.Author,
+		Description: p.Description,
+		Comment:     p.Comment,
+		Source:      p.Source,
+		Difficulty:  p.Difficulty,
+		Date:        p.Date,
+		URL:         p.URL,
+		Seed:        p.Seed,
+	}
+}
+
+// readJSON reads either a single puzzle object or an array of them.
+func readJSON(r io.Reader) ([]Puzzle, error) {
+	data, err := io.ReadAll(r)
+	if (err != nil) {
+		return nil, err
+	}
+
+	var jsonPuzzles []jsonPuzzle
+	if (strings.HasPrefix(strings.TrimSpace(string(data)), "[")) {
+		err = json.Unmarshal(data, &jsonPuzzles)
+	} else {
+		jsonPuzzles = make([]jsonPuzzle, 1)
+		err = json.Unmarshal(data, &jsonPuzzles[0])
+	}
+	if (err != nil) {
+		return nil, err
+	}
+
+	var puzzles []Puzzle
+	for i, jp := range jsonPuzzles {
+		p, err := jp.puzzle()
+		if (err != nil) {
+			return nil, fmt.Errorf("json: puzzle %d: %w", i+1, err)
+		}
+		puzzles = append(puzzles, p)
+	}
+	return puzzles, nil
+}
+
+// puzzle returns the puzzle jp represents.
+func (jp jsonPuzzle) puzzle() (Puzzle, error) {
+	if (jp.Grid == "") {
+		return Puzzle{}, errors.New("no grid")
+	}
+	cells, err := ParseCells(jp.Grid)
+	if (err != nil) {
+		return Puzzle{}, err
+	}
+	var solution [9][9]int
+	if (jp.Solution != "") {
+		solution, err = ParseCells(jp.Solution)
+		if (err != nil) {
+			return Puzzle{}, fmt.Errorf("solution: %w", err)
+		}
+	}
+	if (jp.Candidates != nil) {
+		err = checkCandidates(*jp.Candidates)
+		if (err != nil) {
+			return Puzzle{}, err
+		}
+	}
+	return Puzzle{
+		Cells:       cells,
+		Title:       jp.Title,
+		Technique:   jp.Technique,
+		Candidates:  jp.Candidates,
+		Solution:    solution,
+		Author:      jp.Author,
+		Description: jp.Description,
+		Comment:     jp.Comment,
+		Source:      jp.Source,
+		Difficulty:  jp.Difficulty,
+		Date:        jp.Date,
+		URL:         jp.URL,
+		Seed:        jp.Seed,
+	}, nil
+}
+
+// readJSONLines reads one puzzle object per line, skipping blank lines.
+func readJSONLines(r io.Reader) ([]Puzzle, error) {
+	var puzzles []Puzzle
+	var scanner = bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	var line int = 0
+	for (scanner.Scan()) {
+		line++
+		var text = strings.TrimSpace(scanner.Text())
+		if (text == "") {
+			continue
+		}
+		var jp jsonPuzzle
+		err := json.Unmarshal([]byte(text), &jp)
+		if (err != nil) {
+			return nil, fmt.Errorf("jsonl: line %d: %w", line, err)
+		}
+		p, err := jp.puzzle()
+		if (err != nil) {
+			return nil, fmt.Errorf("jsonl: line %d: %w", line, err)
+		}
+		puzzles = append(puzzles, p)
+	}
+	return puzzles, scanner.Err()
+}
+
+// writeJSONLines writes each puzzle as a JSON object on its own line.
+func writeJSONLines(w io.Writer, puzzles []Puzzle) error {
+	var encoder = json.NewEncoder(w)
+	for _, p := range puzzles {
+		err := encoder.Encode(newJSONPuzzle(p))
+		if (err != nil) {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, puzzles []Puzzle) error {
+	if (len(puzzles) == 0) {
+		return errors.New("json: no puzzle to write")
+	}
+
+	var jsonPuzzles []jsonPuzzle
+	for _, p := range puzzles {
+		jsonPuzzles = append(jsonPuzzles, newJSONPuzzle(p))
+	}
+
+	var encoder = json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if (len(jsonPuzzles) == 1) {
+		return encoder.Encode(jsonPuzzles[0])
+	}
+	return encoder.Encode(jsonPuzzles)
+}
+
+// readCSV reads puzzles of nine records of nine values each. Empty
+// cells are blank or 0, and blank lines between puzzles are skipped.
+func readCSV(r io.Reader) ([]Puzzle, error) {
+	var reader = csv.NewReader(r)
+	reader.FieldsPerRecord = 9
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if (err != nil) {
+		return nil, fmt.Errorf("csv: %w", err)
+	}
+	if (len(records) == 0 || len(records)%9 != 0) {
+		return nil, fmt.Errorf("csv: %d rows, expected 9 per puzzle", len(records))
+	}
+
+	var puzzles []Puzzle
+	for start := 0; start < len(records); start += 9 {
+		var str strings.Builder
+		for _, record := range records[start : start+9] {
+			for _, field := range record {
+				field = strings.TrimSpace(field)
+				if (field == "") {
+					field = "0"
+				}
+				str.WriteString(field)
+			}
+		}
+		cells, err := ParseCells(str.String())
+		if (err != nil) {
+			return nil, fmt.Errorf("csv: puzzle %d: %w", start/9+1, err)
+		}
+		puzzles = append(puzzles, Puzzle{Cells: cells})
+	}
+	return puzzles, nil
+}
+
+// writeCSV writes each puzzle as nine records, empty cells blank.
+func writeCSV(w io.Writer, puzzles []Puzzle) error {
+	var writer = csv.NewWriter(w)
+	for _, p := range puzzles {
+		for row := 0; row < 9; row++ {
+			var record = make([]string, 9)
+			for col := 0; col < 9; col++ {
+				if (p.Cells[row][col] != 0) {
+					record[col] = fmt.Sprint(p.Cells[row][col])
+				}
+			}
+			writer.Write(record)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
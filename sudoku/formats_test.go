@@ -0,0 +1,220 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const formatsPuzzle = "004209000900000020000367000500000710100080903600903000070000501000001040800000000"
+
+func TestParseEmptyCells(t *testing.T) {
+	var tests = []string{
+		formatsPuzzle,
+		strings.ReplaceAll(formatsPuzzle, "0", "."),
+		strings.ReplaceAll(formatsPuzzle, "0", "_"),
+		"..42_9000" + formatsPuzzle[9:],
+	}
+	for _, test := range tests {
+		g, err := Parse(test)
+		if (err != nil) {
+			t.Errorf("%s: %v", test, err)
+		} else if (g.String() != formatsPuzzle) {
+			t.Errorf("%s: got %s, want %s", test, g.String(), formatsPuzzle)
+		}
+	}
+
+	for _, test := range []string{"x" + formatsPuzzle[1:], "-" + formatsPuzzle[1:]} {
+		_, err := Parse(test)
+		if (!errors.Is(err, ErrInvalidChar)) {
+			t.Errorf("%s: got %v, want ErrInvalidChar", test, err)
+		}
+	}
+}
+
+const sdkFile = `#A Jane Doe
+#D A gentle start
+#B 2024-03-01
+#L Easy
+#U https://example.com/puzzle/1
+[Puzzle]
+..42.9...
+9......2.
+...367...
+5.....71.
+1...8.9.3
+6..9.3...
+.7....5.1
+.....1.4.
+8........
+[State]
+714259386
+`
+
+func TestSdk(t *testing.T) {
+	puzzles, err := ReadPuzzles(strings.NewReader(sdkFile), "sdk")
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	var want = Puzzle{
+		Author:      "Jane Doe",
+		Description: "A gentle start",
+		Date:        "2024-03-01",
+		Difficulty:  "Easy",
+		URL:         "https://example.com/puzzle/1",
+	}
+	want.Cells, _ = ParseCells(formatsPuzzle)
+	if (len(puzzles) != 1 || !reflect.DeepEqual(puzzles[0], want)) {
+		t.Fatalf("got %+v, want %+v", puzzles, want)
+	}
+
+	var buf strings.Builder
+	err = WritePuzzles(&buf, "sdk", puzzles)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	again, err := ReadPuzzles(strings.NewReader(buf.String()), "sdk")
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	if (len(again) != 1 || !reflect.DeepEqual(again[0], want)) {
+		t.Errorf("written back as %q, read as %+v", buf.String(), again)
+	}
+
+	_, err = ReadPuzzles(strings.NewReader("#A Jane Doe\n..42.9...\n"), "sdk")
+	if (err == nil) {
+		t.Error("a single row: got no error")
+	}
+}
+
+func TestCSV(t *testing.T) {
+	var file = `,,4,2,,9,,,
+9,,,,,,,2,
+,,,3,6,7,,,
+5,,,,,,7,1,
+1,,,,8,,9,,3
+6,,,9,,3,,,
+,7,,,,,5,,1
+,,,,,1,,4,
+8,,,,,,,,
+
+7,3,4,2,5,9,1,8,6
+9,6,5,1,4,8,3,2,7
+2,1,8,3,6,7,4,9,5
+5,9,3,4,2,6,7,1,8
+1,4,2,7,8,5,9,6,3
+6,8,7,9,1,3,2,5,4
+4,7,6,8,9,2,5,3,1
+3,5,9,6,7,1,8,4,2
+8,2,1,5,3,4,6,7,0
+`
+	puzzles, err := ReadPuzzles(strings.NewReader(file), "csv")
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	var want = []string{formatsPuzzle, "734259186965148327218367495593426718142785963687913254476892531359671842821534670"}
+	if (len(puzzles) != len(want)) {
+		t.Fatalf("got %d puzzles, want %d", len(puzzles), len(want))
+	}
+	for i, p := range puzzles {
+		if (CellsString(p.Cells, '0') != want[i]) {
+			t.Errorf("puzzle %d: got %s, want %s", i+1, CellsString(p.Cells, '0'), want[i])
+		}
+	}
+
+	var buf strings.Builder
+	err = WritePuzzles(&buf, "csv", puzzles[:1])
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	if (buf.String() != file[:strings.Index(file, "\n\n")+1]) {
+		t.Errorf("got\n%s", buf.String())
+	}
+
+	_, err = ReadPuzzles(strings.NewReader(file[:strings.Index(file, "\n")+1]), "csv")
+	if (err == nil) {
+		t.Error("a single row: got no error")
+	}
+	_, err = ReadPuzzles(strings.NewReader(strings.Replace(file, "9,,,,,,,2,", "9,,,,,,,2", 1)), "csv")
+	if (err == nil) {
+		t.Error("a row of 8 values: got no error")
+	}
+}
+
+func TestJSONPuzzle(t *testing.T) {
+	var file = `{
+		"grid": "` + formatsPuzzle + `",
+		"title": "Gentle",
+		"author": "Jane Doe",
+		"candidates": [[[3, 7], [], [], [], [1, 5]]],
+		"solution": "734259186965148327218367495593426718142785963687913254476892531359671842821534679"
+	}`
+	puzzles, err := ReadPuzzles(strings.NewReader(file), "json")
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	if (len(puzzles) != 1) {
+		t.Fatalf("got %d puzzles, want 1", len(puzzles))
+	}
+	var p = puzzles[0]
+	if (CellsString(p.Cells, '0') != formatsPuzzle || p.Title != "Gentle" || p.Author != "Jane Doe") {
+		t.Errorf("got %+v", p)
+	}
+	if (p.Candidates == nil || !reflect.DeepEqual(p.Candidates[0][0], []int{3, 7}) || !reflect.DeepEqual(p.Candidates[0][4], []int{1, 5})) {
+		t.Errorf("got candidates %v", p.Candidates)
+	}
+	if (CellsString(p.Solution, '0') != "734259186965148327218367495593426718142785963687913254476892531359671842821534679") {
+		t.Errorf("got solution %s", CellsString(p.Solution, '0'))
+	}
+
+	var buf strings.Builder
+	err = WritePuzzles(&buf, "json", puzzles)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	if (strings.Contains(buf.String(), "null")) {
+		t.Errorf("wrote candidates as null rather than []: %s", buf.String())
+	}
+	again, err := ReadPuzzles(strings.NewReader(buf.String()), "json")
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	var rewritten strings.Builder
+	err = WritePuzzles(&rewritten, "json", again)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	if (rewritten.String() != buf.String() || again[0].Title != p.Title || again[0].Solution != p.Solution) {
+		t.Errorf("written back as %s, then as %s", buf.String(), rewritten.String())
+	}
+
+	_, err = ReadPuzzles(strings.NewReader(strings.Replace(file, "[1, 5]", "[1, 10]", 1)), "json")
+	if (err == nil || !strings.Contains(err.Error(), "r1c5")) {
+		t.Errorf("candidate 10: got %v, want an error naming r1c5", err)
+	}
+}
+
+func TestNarrowOptions(t *testing.T) {
+	g, err := Parse(formatsPuzzle)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	g.ListOptions()
+	var known [9][9][]int
+	known[0][0] = []int{7}
+	known[0][4] = []int{5, 8}
+	removed, err := g.NarrowOptions(known)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	if (removed != 2 || !reflect.DeepEqual(g.Candidates(0, 0), []int{7}) || !reflect.DeepEqual(g.Candidates(0, 4), []int{5})) {
+		t.Errorf("removed %d options, leaving %v in r1c1 and %v in r1c5", removed, g.Candidates(0, 0), g.Candidates(0, 4))
+	}
+
+	known[0][0] = []int{0, 7}
+	_, err = g.NarrowOptions(known)
+	if (err == nil || !strings.Contains(err.Error(), "r1c1")) {
+		t.Errorf("candidate 0: got %v, want an error naming r1c1", err)
+	}
+}
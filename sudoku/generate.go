@@ -0,0 +1,124 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+// DefaultGenerateTries is the number of puzzles Generate tries when
+// GenerateOptions.Tries is 0.
+const DefaultGenerateTries = 1000
+
+// ErrGenerateTries is returned when Generate tried as many puzzles as
+// allowed without finding one of the difficulty and clues asked for.
+var ErrGenerateTries = errors.New("no puzzle of the difficulty and clues asked for was found, try again or widen them")
+
+// GenerateOptions tune Generate.
+type GenerateOptions struct {
+	Symmetry Symmetry   // pattern of the clues, see Harden
+	Rand     *rand.Rand // source of the puzzle, seeded from the time when nil
+
+	// Minimal removes the clues left by the symmetry which are not
+	// needed, breaking it. Without symmetry, puzzles are always minimal.
+	Minimal bool
+
+	// Difficulty of the puzzle, see Rate: its level, "" for any, and
+	// its score range, 0 for no bound.
+	Level    string
+	MinScore float64
+	MaxScore float64
+
+	// Technique is the hardest technique the puzzle needs, one of
+	// DefaultStrategies, "" for any. Clues are only removed while the
+	// puzzle needs nothing harder, and puzzles are generated until one
+	// needs it.
+	Technique string
+
+	// Number of clues of the puzzle, 0 for no bound. Clues are not
+	// removed below MinClues, so some of them may not be needed.
+	MinClues int
+	MaxClues int
+
+	Tries int // puzzles tried for the difficulty, DefaultGenerateTries when 0
+}
+
+// Generate returns a random puzzle with a single solution: it fills a
+// random grid, then removes clues with Harden while the solution stays
+// unique, so that every clue left is needed, unless the symmetry or
+// the technique asked for keeps some of them. Puzzles are generated
+// until one has the number of clues and, as Rate confirms, the
+// difficulty asked for.
+func Generate(opts GenerateOptions) (*Grid, error) {
+	if (opts.Technique != "" && !slices.ContainsFunc(DefaultStrategies(), func(strategy Strategy) bool {
+		return strategy.Name() == opts.Technique
+	})) {
+		return nil, fmt.Errorf("technique %q is not one of the default strategies", opts.Technique)
+	}
+	if (opts.MaxClues > 0 && opts.MinClues > opts.MaxClues) {
+		return nil, fmt.Errorf("%d clues at least is more than %d at most", opts.MinClues, opts.MaxClues)
+	}
+	var r = opts.Rand
+	if (r == nil) {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	var tries = opts.Tries
+	if (tries <= 0) {
+		tries = DefaultGenerateTries
+	}
+
+	for try := 0; try < tries; try++ {
+		var solution = NewGrid(randomSolution(r))
+		puzzle, err := solution.Harden(HardenOptions{Symmetry: opts.Symmetry, Rand: r, Technique: opts.Technique, MinClues: opts.MinClues})
+		if (err != nil) {
+			return nil, err
+		}
+		if (opts.Minimal && opts.Symmetry != NoSymmetry) {
+			puzzle, err = puzzle.Harden(HardenOptions{Rand: r, Technique: opts.Technique, MinClues: opts.MinClues})
+			if (err != nil) {
+				return nil, err
+			}
+		}
+		if (opts.MaxClues > 0 && 81-puzzle.CountEmptyCells() > opts.MaxClues) {
+			continue
+		}
+		if (opts.matches(Rate(*puzzle))) {
+			return puzzle, nil
+		}
+	}
+	return nil, ErrGenerateTries
+}
+
+// matches returns true if the rating has the difficulty of the options.
+func (opts GenerateOptions) matches(rating Rating) bool {
+	if (opts.Technique != "" && rating.Hardest != opts.Technique) {
+		return false
+	}
+	if (opts.Level != "" && rating.Level != opts.Level) {
+		return false
+	}
+	if (opts.MinScore > 0 && rating.Score < opts.MinScore) {
+		return false
+	}
+	return opts.MaxScore <= 0 || rating.Score <= opts.MaxScore
+}
+
+// randomSolution returns a random full grid: the squares on the
+// diagonal, which do not see each other, are filled with shuffled
+// values, and the rest is completed with Dancing Links.
+func randomSolution(r *rand.Rand) [9][9]int {
+	var cells [9][9]int
+	for square := 0; square < 3; square++ {
+		var values = r.Perm(9)
+		for i, value := range values {
+			cells[square*3+i/3][square*3+i%3] = value + 1
+		}
+	}
+	dlxSearch(givenSets(cells), func(solution [9][9]int) bool {
+		cells = solution
+		return false
+	})
+	return cells
+}
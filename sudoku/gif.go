@@ -0,0 +1,79 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+)
+
+// GIFOptions tell how WriteGIF animates the solve.
+type GIFOptions struct {
+	// Size is the width and height of the image, in pixels.
+	Size int
+
+	// Delay is the time each step is shown, in hundredths of a second.
+	// The last frame stays four times longer before looping.
+	Delay int
+}
+
+// gifPalette holds the colors of the PNG drawing, the only ones of the
+// frames.
+var gifPalette = color.Palette{
+	color.White,
+	pngGivenColor,
+	pngPlacedColor,
+	pngMarkColor,
+	pngThinColor,
+	pngHighlightColor,
+}
+
+// WriteGIF writes an animated GIF of the solve: the first frame shows
+// start, and each following one applies a step recorded from the
+// solver, with OnPlacement and OnElimination, highlighting its cell.
+func WriteGIF(w io.Writer, start *Grid, steps []Step, opts GIFOptions) error {
+	if (opts.Size < 90) {
+		return fmt.Errorf("image size %d is too small, use at least 90 pixels", opts.Size)
+	}
+	if (opts.Delay <= 0) {
+		return errors.New("gif: the delay between steps must be positive")
+	}
+
+	var anim gif.GIF
+	var addFrame = func(g *Grid, highlight []Coord) {
+		var img = g.drawImage(opts.Size, highlight)
+		var frame = image.NewPaletted(img.Bounds(), gifPalette)
+		draw.Draw(frame, frame.Bounds(), img, image.Point{}, draw.Src)
+		anim.Image = append(anim.Image, frame)
+		anim.Delay = append(anim.Delay, opts.Delay)
+	}
+
+	var g = start.Clone()
+	addFrame(g, nil)
+	for _, step := range steps {
+		g.replay(step)
+		addFrame(g, []Coord{{step.Row, step.Col}})
+	}
+	addFrame(g, nil)
+	anim.Delay[len(anim.Delay)-1] = 4 * opts.Delay
+
+	return gif.EncodeAll(w, &anim)
+}
+
+// replay applies a step recorded from the solver to the grid.
+// Placements remove their value from the options of the peers of the
+// cell, as the solver does.
+func (g *Grid) replay(step Step) {
+	if (step.Kind == Elimination) {
+		g.Eliminate(step.Row, step.Col, step.Value)
+		return
+	}
+	g.cells[step.Row][step.Col] = step.Value
+	g.options[step.Row][step.Col] = 0
+	for _, peer := range Peers(step.Row, step.Col) {
+		g.Eliminate(peer.Row, peer.Col, step.Value)
+	}
+}
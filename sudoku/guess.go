@@ -0,0 +1,121 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+)
+
+// guessOutcome is what happens when the solver runs after a guess.
+type guessOutcome int
+
+const (
+	guessStalled guessOutcome = iota
+	guessSolved
+	guessContradiction
+)
+
+// Guess is the result of trying a value in a bivalue cell.
+type Guess struct {
+	Row, Col, Value int
+
+	// Eliminated is the other value of the cell when it leads to a
+	// contradiction, which makes Value a deduction rather than a guess,
+	// or 0.
+	Eliminated int
+
+	outcome   guessOutcome
+	remaining int // empty cells left when the solver stopped
+}
+
+// tryGuess sets value in the given cell, runs the solver as far as it
+// goes, and restores the grid before returning what happened.
+func (s *Solver) tryGuess(g *Grid, row int, col int, value int) Guess {
+	var saved = *g
+	g.Verbose = false
+	g.OnEvent = nil
+	defer func() {
+		*g = saved
+	}()
+
+	g.place(Coord{row, col}, value)
+
+	var trial = Guess{Row: row, Col: col, Value: value}
+	for {
+		if (g.contradiction() != nil) {
+			trial.outcome = guessContradiction
+			break
+		}
+		if (g.CountEmptyCells() == 0) {
+			trial.outcome = guessSolved
+			break
+		}
+		changed, err := s.Step(g)
+		if (errors.Is(err, ErrNoSolution)) {
+			trial.outcome = guessContradiction
+			break
+		}
+		if (err != nil || !changed) {
+			break
+		}
+	}
+	trial.remaining = g.CountEmptyCells()
+	return trial
+}
+
+// SuggestGuess tries both values of every bivalue cell with the
+// default strategies, see Solver.SuggestGuess.
+func (g *Grid) SuggestGuess() (Guess, string, bool) {
+	return NewSolver().SuggestGuess(g)
+}
+
+// SuggestGuess tries both values of every bivalue cell of g, running
+// the strategies of the solver after each, and recommends the safest
+// guess, with the reason it was chosen. In order of preference: a value
+// whose alternative leads to a contradiction, which is no guess but an
+// elimination, see Guess.Eliminated, a value leading to a full
+// solution, then the value leaving the fewest empty cells.
+func (s *Solver) SuggestGuess(g *Grid) (Guess, string, bool) {
+	// the trials are not reported to the observers of s
+	var trialSolver = &Solver{Strategies: s.Strategies}
+	var best Guess
+	var reason string
+	var rank int = -1
+
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (g.cells[row][col] != 0 || g.options[row][col].count() != 2) {
+				continue
+			}
+
+			var options = g.options[row][col].values()
+			var a = trialSolver.tryGuess(g, row, col, options[0])
+			var b = trialSolver.tryGuess(g, row, col, options[1])
+			for _, pair := range [][2]Guess{{a, b}, {b, a}} {
+				var trial, other = pair[0], pair[1]
+				var trialRank int
+				var trialReason string
+				switch {
+				case trial.outcome == guessContradiction:
+					continue
+				case other.outcome == guessContradiction:
+					trial.Eliminated = other.Value
+					trialRank = 3
+					trialReason = fmt.Sprintf("r%dc%d = %d leads to a contradiction", row+1, col+1, other.Value)
+				case trial.outcome == guessSolved:
+					trialRank = 2
+					trialReason = "it leads to a complete solution"
+				default:
+					trialRank = 1
+					trialReason = fmt.Sprintf("the solver then leaves %d empty cells, the fewest of all bivalue cells", trial.remaining)
+				}
+
+				var better bool = trialRank > rank ||
+					(trialRank == rank && trialRank == 1 && trial.remaining < best.remaining)
+				if (better) {
+					best, reason, rank = trial, trialReason, trialRank
+				}
+			}
+		}
+	}
+	return best, reason, rank >= 0
+}
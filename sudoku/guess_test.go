@@ -0,0 +1,48 @@
+package sudoku
+
+import (
+	"testing"
+)
+
+func TestSuggestGuess(t *testing.T) {
+	var tests = []struct {
+		strategies []string
+		cell       Coord
+	}{
+		{[]string{"naked-single"}, Coord{0, 8}},
+		{[]string{"naked-single", "hidden-single"}, Coord{0, 6}},
+	}
+	for _, test := range tests {
+		strategies, err := StrategiesByName(test.strategies)
+		if (err != nil) {
+			t.Fatal(err)
+		}
+		var solver = &Solver{Strategies: strategies}
+		var observed int = 0
+		solver.OnPlacement(func(Step) {
+			observed++
+		})
+		g, err := Parse(nishioPuzzle)
+		if (err != nil) {
+			t.Fatal(err)
+		}
+		g.ListOptions()
+		solver.Solve(g)
+		var stuck = g.String()
+		observed = 0
+
+		trial, reason, ok := solver.SuggestGuess(g)
+		if (!ok) {
+			t.Fatalf("%v: no guess suggested", test.strategies)
+		}
+		if (g.String() != stuck || observed != 0) {
+			t.Errorf("%v: the trials changed the grid or were observed", test.strategies)
+		}
+		// a value leading to a contradiction is ruled out, leaving the
+		// one of the solution
+		var solution = int(nishioSolution[trial.Row*9+trial.Col] - '0')
+		if ((Coord{trial.Row, trial.Col}) != test.cell || trial.Eliminated == 0 || trial.Value != solution) {
+			t.Errorf("%v: suggested %+v because %s, want an elimination in %v leaving %d", test.strategies, trial, reason, test.cell, solution)
+		}
+	}
+}
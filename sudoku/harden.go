@@ -0,0 +1,123 @@
+package sudoku
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Symmetry is the pattern the clues of a puzzle keep as Harden removes
+// them: each clue goes along with its images.
+type Symmetry string
+
+const (
+	NoSymmetry Symmetry = "none"
+	Rotational Symmetry = "rotational" // half turn around the center
+	Horizontal Symmetry = "horizontal" // mirror across the middle row
+	Vertical   Symmetry = "vertical"   // mirror across the middle column
+	Diagonal   Symmetry = "diagonal"   // mirror across the main diagonal
+)
+
+// Symmetries are the supported symmetries, NoSymmetry first.
+var Symmetries = []Symmetry{NoSymmetry, Rotational, Horizontal, Vertical, Diagonal}
+
+// ParseSymmetry returns the symmetry with the given name.
+func ParseSymmetry(name string) (Symmetry, error) {
+	for _, symmetry := range Symmetries {
+		if (string(symmetry) == name) {
+			return symmetry, nil
+		}
+	}
+	return NoSymmetry, fmt.Errorf("unknown symmetry %q", name)
+}
+
+// orbit returns the cell and its images by the symmetry.
+func (s Symmetry) orbit(cell Coord) []Coord {
+	var image Coord
+	switch s {
+	case Rotational:
+		image = Coord{8 - cell.Row, 8 - cell.Col}
+	case Horizontal:
+		image = Coord{8 - cell.Row, cell.Col}
+	case Vertical:
+		image = Coord{cell.Row, 8 - cell.Col}
+	case Diagonal:
+		image = Coord{cell.Col, cell.Row}
+	default:
+		return []Coord{cell}
+	}
+	if (image == cell) {
+		return []Coord{cell}
+	}
+	return []Coord{cell, image}
+}
+
+// HardenOptions tune Harden.
+type HardenOptions struct {
+	Symmetry Symmetry
+	Rand     *rand.Rand // order the clues are tried in, row by row when nil
+
+	// Technique is the hardest technique the puzzle may need, see Rate:
+	// clues are only removed while the puzzle can be solved without
+	// harder ones, so some of those left may not be needed. "" for any.
+	Technique string
+
+	MinClues int // clues not to remove below, some of them may not be needed
+}
+
+// Harden returns the puzzle g with as many clues removed as possible,
+// its solution staying unique: each clue, with its images by the
+// symmetry, is tried once, and whatever clues are left are all needed.
+// g may be a full solution. Fewer clues usually make a harder puzzle.
+// It returns ErrNoSolution or a *NotUniqueError when g does not have a
+// single solution.
+func (g *Grid) Harden(opts HardenOptions) (*Grid, error) {
+	var solutions = g.AllSolutions(2)
+	if (len(solutions) == 0) {
+		return nil, ErrNoSolution
+	}
+	if (len(solutions) > 1) {
+		return nil, &NotUniqueError{solutions}
+	}
+
+	var order []Coord
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			order = append(order, Coord{row, col})
+		}
+	}
+	if (opts.Rand != nil) {
+		opts.Rand.Shuffle(len(order), func(i int, j int) {
+			order[i], order[j] = order[j], order[i]
+		})
+	}
+
+	var cells = g.cells
+	var clues int = 81 - g.CountEmptyCells()
+	for _, cell := range order {
+		if (cells[cell.Row][cell.Col] == 0) {
+			continue
+		}
+		var removed = cells
+		var left = clues
+		for _, image := range opts.Symmetry.orbit(cell) {
+			if (removed[image.Row][image.Col] != 0) {
+				removed[image.Row][image.Col] = 0
+				left--
+			}
+		}
+		if (left >= opts.MinClues && countSolutions(removed, 2) == 1 && opts.allows(NewGrid(removed))) {
+			cells, clues = removed, left
+		}
+	}
+	return NewGrid(cells), nil
+}
+
+// allows returns true if g needs no technique harder than the one of
+// the options.
+func (opts HardenOptions) allows(g *Grid) bool {
+	if (opts.Technique == "") {
+		return true
+	}
+	var rating = Rate(*g)
+	return rating.Solved && techniqueWeight(rating.Hardest) <= techniqueWeight(opts.Technique)
+}
@@ -0,0 +1,70 @@
+package sudoku
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Hash returns a stable identifier of the grid values: the hex SHA-256
+// of its 81 digits string. Options are not taken into account, so a
+// puzzle has the same hash whatever its solving state.
+func (g *Grid) Hash() string {
+	var sum = sha256.Sum256([]byte(g.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// HashString returns the hash of a grid given as a string, see Parse.
+func HashString(str string) (string, error) {
+	g, err := Parse(str)
+	if (err != nil) {
+		return "", err
+	}
+	return g.Hash(), nil
+}
+
+// Catalog stores grids by hash, to look them up or skip duplicates.
+type Catalog struct {
+	byHash map[string]*Grid
+	hashes []string // in insertion order
+}
+
+// NewCatalog returns an empty catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{byHash: make(map[string]*Grid)}
+}
+
+// Add stores a copy of g and returns its hash. It returns false if the
+// catalog already held a grid with the same values.
+func (c *Catalog) Add(g *Grid) (string, bool) {
+	var hash = g.Hash()
+	_, found := c.byHash[hash]
+	if (found) {
+		return hash, false
+	}
+	c.byHash[hash] = g.Clone()
+	c.hashes = append(c.hashes, hash)
+	return hash, true
+}
+
+// Lookup returns the grid stored with the given hash.
+func (c *Catalog) Lookup(hash string) (*Grid, bool) {
+	g, found := c.byHash[hash]
+	return g, found
+}
+
+// Contains returns true if the catalog holds a grid with the values
+// of g.
+func (c *Catalog) Contains(g *Grid) bool {
+	_, found := c.byHash[g.Hash()]
+	return found
+}
+
+// Hashes returns the hashes of the stored grids, in insertion order.
+func (c *Catalog) Hashes() []string {
+	return append([]string(nil), c.hashes...)
+}
+
+// Len returns the number of stored grids.
+func (c *Catalog) Len() int {
+	return len(c.hashes)
+}
@@ -0,0 +1,45 @@
+package sudoku
+
+// NextDeduction returns the first deduction the solver makes on g,
+// with the house and the reason explaining it when there are, without
+// changing g. It returns false when the grid is full. When the
+// strategies are stuck, the error tells why, as for Solver.Solve.
+func (s *Solver) NextDeduction(g *Grid) (Event, bool, error) {
+	var trial = g.Clone()
+	trial.Verbose = false
+	if (!trial.listed) {
+		trial.ListOptions()
+	}
+	var events []Event
+	trial.OnEvent = func(e Event) {
+		events = append(events, e)
+	}
+
+	var steps []Step
+	var record = func(step Step) {
+		steps = append(steps, step)
+	}
+	var trialSolver = &Solver{Strategies: s.Strategies}
+	trialSolver.OnPlacement(record)
+	trialSolver.OnElimination(record)
+	changed, err := trialSolver.Step(trial)
+	if (err != nil) {
+		return Event{}, false, err
+	}
+	if (!changed || len(steps) == 0) {
+		if (trial.CountEmptyCells() == 0) {
+			return Event{}, false, nil
+		}
+		return Event{}, false, s.diagnose(trial)
+	}
+
+	// events are not always emitted in the order of the steps
+	var first = steps[0]
+	var cell = Coord{first.Row, first.Col}
+	for _, e := range events {
+		if (e.Kind == first.Kind && e.Technique == first.Technique && e.Cell == cell && e.Digit == first.Value) {
+			return e, true, nil
+		}
+	}
+	return Event{Kind: first.Kind, Technique: first.Technique, Cell: cell, Digit: first.Value}, true, nil
+}
@@ -0,0 +1,206 @@
+package sudoku
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// hodokuTechniques maps the technique codes of HoDoKu libraries to
+// their names. Unknown codes are kept as they are.
+var hodokuTechniques = map[string]string{
+	"0000": "Full House",
+	"0002": "Hidden Single",
+	"0003": "Naked Single",
+	"0100": "Locked Candidates Type 1 (Pointing)",
+	"0101": "Locked Candidates Type 2 (Claiming)",
+	"0110": "Locked Pair",
+	"0111": "Locked Triple",
+	"0200": "Naked Pair",
+	"0201": "Naked Triple",
+	"0202": "Naked Quadruple",
+	"0210": "Hidden Pair",
+	"0211": "Hidden Triple",
+	"0212": "Hidden Quadruple",
+	"0300": "X-Wing",
+	"0301": "Swordfish",
+	"0302": "Jellyfish",
+	"0400": "Skyscraper",
+	"0401": "2-String Kite",
+	"0402": "Turbot Fish",
+	"0403": "Empty Rectangle",
+	"0600": "Uniqueness Test 1",
+	"0601": "Uniqueness Test 2",
+	"0602": "Uniqueness Test 3",
+	"0603": "Uniqueness Test 4",
+	"0610": "Bivalue Universal Grave + 1",
+	"0701": "X-Chain",
+	"0702": "XY-Chain",
+	"0703": "Remote Pair",
+	"0800": "XY-Wing",
+	"0801": "XYZ-Wing",
+	"0803": "W-Wing",
+}
+
+// readHodoku reads a HoDoKu library: one puzzle per line, in the form
+//   :technique:digits:grid:deleted:eliminations:placements:extra:
+// where technique is a code such as 0300 (X-Wing), grid holds the 81
+// cells with + before the values placed after the givens, and deleted,
+// eliminations and placements list candidates as "digit row col"
+// triplets, e.g. 413 for 4 in r1c3. The deleted candidates are taken
+// out of the puzzle Candidates, and the eliminations and placements the
+// technique leads to are stored in Expected. Lines not starting with :
+// are comments.
+func readHodoku(r io.Reader) ([]Puzzle, error) {
+	var puzzles []Puzzle
+	var scanner = bufio.NewScanner(r)
+	var lineNum int = 0
+	for scanner.Scan() {
+		lineNum++
+		var line = strings.TrimSpace(scanner.Text())
+		if (!strings.HasPrefix(line, ":")) {
+			continue
+		}
+
+		p, err := parseHodokuLine(line)
+		if (err != nil) {
+			return nil, fmt.Errorf("hodoku: line %d: %w", lineNum, err)
+		}
+		puzzles = append(puzzles, p)
+	}
+	return puzzles, scanner.Err()
+}
+
+// parseHodokuLine reads one puzzle of a HoDoKu library, see readHodoku.
+func parseHodokuLine(line string) (Puzzle, error) {
+	var fields = strings.Split(strings.TrimPrefix(line, ":"), ":")
+	if (len(fields) < 4) {
+		return Puzzle{}, fmt.Errorf("expected at least 4 fields, got %d", len(fields))
+	}
+	for len(fields) < 6 {
+		fields = append(fields, "")
+	}
+
+	var p Puzzle
+	var code = fields[0]
+	p.Technique = code
+	name, ok := hodokuTechniques[strings.SplitN(code, "-", 2)[0]]
+	if (ok) {
+		p.Technique = name
+	}
+
+	cells, err := ParseCells(strings.ReplaceAll(fields[2], "+", ""))
+	if (err != nil) {
+		return Puzzle{}, err
+	}
+	p.Cells = cells
+
+	var g = NewGrid(cells)
+	g.ListOptions()
+	deleted, err := parseHodokuCandidates(fields[3])
+	if (err != nil) {
+		return Puzzle{}, fmt.Errorf("deleted candidates: %w", err)
+	}
+	for _, c := range deleted {
+		g.Eliminate(c.Row, c.Col, c.Value)
+	}
+	var candidates = g.Options()
+	p.Candidates = &candidates
+
+	eliminations, err := parseHodokuCandidates(fields[4])
+	if (err != nil) {
+		return Puzzle{}, fmt.Errorf("eliminations: %w", err)
+	}
+	placements, err := parseHodokuCandidates(fields[5])
+	if (err != nil) {
+		return Puzzle{}, fmt.Errorf("placements: %w", err)
+	}
+	for _, c := range eliminations {
+		p.Expected = append(p.Expected, Step{Elimination, p.Technique, c.Row, c.Col, c.Value})
+	}
+	for _, c := range placements {
+		p.Expected = append(p.Expected, Step{Placement, p.Technique, c.Row, c.Col, c.Value})
+	}
+	return p, nil
+}
+
+// hodokuCandidate is a value in a cell, written "digit row col".
+type hodokuCandidate struct {
+	Coord
+	Value int
+}
+
+// parseHodokuCandidates reads a space-separated list of candidates
+// such as "413 517".
+func parseHodokuCandidates(str string) ([]hodokuCandidate, error) {
+	var candidates []hodokuCandidate
+	for _, field := range strings.Fields(str) {
+		if (len(field) != 3 || strings.Trim(field, "123456789") != "") {
+			return nil, fmt.Errorf("invalid candidate %q, expected digit, row and column", field)
+		}
+		candidates = append(candidates, hodokuCandidate{
+			Coord: Coord{int(field[1]-'1'), int(field[2]-'1')},
+			Value: int(field[0] - '0'),
+		})
+	}
+	return candidates, nil
+}
+
+// writeHodoku writes puzzles as a HoDoKu library, see readHodoku: the
+// technique is written as its code when it has one, the candidates of
+// the puzzle missing from its listed options as deleted, and Expected
+// as eliminations and placements.
+func writeHodoku(w io.Writer, puzzles []Puzzle) error {
+	var codes = make(map[string]string)
+	for code, name := range hodokuTechniques {
+		codes[name] = code
+	}
+
+	for _, p := range puzzles {
+		var technique = p.Technique
+		code, ok := codes[technique]
+		if (ok) {
+			technique = code
+		}
+
+		var g = NewGrid(p.Cells)
+		g.ListOptions()
+		var deleted []string
+		if (p.Candidates != nil) {
+			for row := 0; row < 9; row++ {
+				for col := 0; col < 9; col++ {
+					for _, value := range g.options[row][col].values() {
+						if (!slices.Contains(p.Candidates[row][col], value)) {
+							deleted = append(deleted, fmt.Sprintf("%d%d%d", value, row+1, col+1))
+						}
+					}
+				}
+			}
+		}
+
+		var digits digitSet = 0
+		var eliminations, placements []string
+		for _, step := range p.Expected {
+			var candidate = fmt.Sprintf("%d%d%d", step.Value, step.Row+1, step.Col+1)
+			digits |= 1 << step.Value
+			if (step.Kind == Placement) {
+				placements = append(placements, candidate)
+			} else {
+				eliminations = append(eliminations, candidate)
+			}
+		}
+		var digitList string
+		for _, value := range digits.values() {
+			digitList += fmt.Sprint(value)
+		}
+
+		_, err := fmt.Fprintf(w, ":%s:%s:%s:%s:%s:%s::\n", technique, digitList, CellsString(p.Cells, '.'),
+			strings.Join(deleted, " "), strings.Join(eliminations, " "), strings.Join(placements, " "))
+		if (err != nil) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package sudoku
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// hodokuLibrary holds a comment and a puzzle whose r9c9 was placed
+// after the givens, 3 deleted from r1c1, 5 eliminated from r1c2 and 7
+// placed in r1c1.
+const hodokuLibrary = `# a HoDoKu library
+:0300:5:00420900090000002000036700050000071010008090360090300007000050100000104080000000+9:311:512:711:
+`
+
+func TestReadHodoku(t *testing.T) {
+	puzzles, err := ReadPuzzles(strings.NewReader(hodokuLibrary), "hodoku")
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	if (len(puzzles) != 1) {
+		t.Fatalf("read %d puzzles, want 1", len(puzzles))
+	}
+	var p = puzzles[0]
+	if (p.Technique != "X-Wing") {
+		t.Errorf("read technique %q, want X-Wing", p.Technique)
+	}
+	cells, _ := ParseCells("004209000900000020000367000500000710100080903600903000070000501000001040800000009")
+	if (p.Cells != cells) {
+		t.Errorf("read cells %s", CellsString(p.Cells, '0'))
+	}
+	if (p.Candidates == nil || !reflect.DeepEqual(p.Candidates[0][0], []int{7})) {
+		t.Errorf("read candidates %v in r1c1, want [7]", p.Candidates)
+	}
+	var want = []Step{{Elimination, "X-Wing", 0, 1, 5}, {Placement, "X-Wing", 0, 0, 7}}
+	if (!reflect.DeepEqual(p.Expected, want)) {
+		t.Errorf("read expected steps %v, want %v", p.Expected, want)
+	}
+
+	var invalid = []string{
+		":0300:5:0042:",
+		":0300:5:" + strings.Repeat("0", 81) + ":31:",
+		":0300:5:" + strings.Repeat("0", 81) + "::5x2:",
+	}
+	for _, line := range invalid {
+		_, err := ReadPuzzles(strings.NewReader(line), "hodoku")
+		if (err == nil || !strings.Contains(err.Error(), "line 1")) {
+			t.Errorf("%q: got error %v, want one on line 1", line, err)
+		}
+	}
+
+	puzzles, err = ReadPuzzles(strings.NewReader(":9999-1:1:"+strings.Repeat("0", 81)+":"), "hodoku")
+	if (err != nil || len(puzzles) != 1 || puzzles[0].Technique != "9999-1") {
+		t.Errorf("unknown code: got %v, %v", puzzles, err)
+	}
+}
+
+func TestCheckSolution(t *testing.T) {
+	puzzles, err := ReadPuzzles(strings.NewReader(hodokuLibrary), "hodoku")
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	var p = puzzles[0]
+	g, err := Parse(CellsString(p.Cells, '0'))
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	g.ListOptions()
+	_, err = NewSolver().Solve(g)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	err = p.CheckSolution(g.Cells())
+	if (err != nil) {
+		t.Errorf("solved grid: %v", err)
+	}
+
+	// 5 eliminated from r1c2, and the solution has 3 in r1c2
+	var wrong = g.Cells()
+	wrong[0][1] = 5
+	err = p.CheckSolution(wrong)
+	if (err == nil || !strings.Contains(err.Error(), "r1c2")) {
+		t.Errorf("5 in r1c2: got error %v, want one naming r1c2", err)
+	}
+	p.Expected = nil
+	p.Solution = g.Cells()
+	err = p.CheckSolution(wrong)
+	if (err == nil || !strings.Contains(err.Error(), "r1c2")) {
+		t.Errorf("5 in r1c2 with a solution: got error %v, want one naming r1c2", err)
+	}
+	wrong[0][1] = 0
+	err = p.CheckSolution(wrong)
+	if (err != nil) {
+		t.Errorf("empty r1c2: %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package sudoku
+
+import (
+	"fmt"
+)
+
+// HouseKind tells whether a house is a square, a row or a column.
+type HouseKind int
+
+const (
+	SquareHouse HouseKind = iota
+	RowHouse
+	ColHouse
+)
+
+// House is a group of 9 cells which must hold each value once: a
+// square, a row or a column.
+type House struct {
+	Kind  HouseKind
+	Index int // from 0 to 8
+	Cells [9]Coord
+}
+
+// String names the house the way the solver explains its deductions,
+// e.g. "square 3", "row 1" or "col 9".
+func (h House) String() string {
+	switch h.Kind {
+	case SquareHouse:
+		return fmt.Sprintf("square %d", h.Index+1)
+	case RowHouse:
+		return fmt.Sprintf("row %d", h.Index+1)
+	}
+	return fmt.Sprintf("col %d", h.Index+1)
+}
+
+// Contains returns true if the cell is one of the house.
+func (h House) Contains(cell Coord) bool {
+	for _, c := range h.Cells {
+		if (c == cell) {
+			return true
+		}
+	}
+	return false
+}
+
+// houses holds the 9 squares, then the 9 rows, then the 9 columns.
+var houses [27]House
+
+func init() {
+	for i := 0; i < 9; i++ {
+		var rowOffset int = (i / 3) * 3
+		var colOffset int = (i % 3) * 3
+		houses[i] = House{Kind: SquareHouse, Index: i}
+		houses[9+i] = House{Kind: RowHouse, Index: i}
+		houses[18+i] = House{Kind: ColHouse, Index: i}
+		for j := 0; j < 9; j++ {
+			houses[i].Cells[j] = Coord{rowOffset + j/3, colOffset + j%3}
+			houses[9+i].Cells[j] = Coord{i, j}
+			houses[18+i].Cells[j] = Coord{j, i}
+		}
+	}
+}
+
+// Houses returns all 27 houses: the 9 squares, then the 9 rows, then
+// the 9 columns.
+func Houses() []House {
+	return append([]House(nil), houses[:]...)
+}
+
+// HousesOf returns the square, row and column holding the given cell.
+func HousesOf(row int, col int) [3]House {
+	return [3]House{houses[getSquareFromRowCol(row, col)-1], houses[9+row], houses[18+col]}
+}
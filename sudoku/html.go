@@ -0,0 +1,79 @@
+package sudoku
+
+import (
+	"html/template"
+	"io"
+)
+
+// htmlCell is a cell as shown by RenderHTML.
+type htmlCell struct {
+	Value int
+	Given bool
+	Marks [9]int // 0 for the values which are not options
+	Box   string // classes drawing the thick lines of squares
+}
+
+// htmlPage is a self-contained page: the CSS is inline, and candidates
+// are toggled by a checkbox without any script.
+var htmlPage = template.Must(template.New("grid").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Sudoku</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; margin: 2em; }
+table { border-collapse: collapse; border: 3px solid #000; }
+td { width: 3em; height: 3em; padding: 0; border: 1px solid #999; text-align: center; vertical-align: middle; font-size: 1.6em; }
+td.right { border-right: 3px solid #000; }
+td.bottom { border-bottom: 3px solid #000; }
+td.given { font-weight: bold; color: #000; }
+td.placed { color: #1a5fb4; }
+.marks { display: grid; grid-template-columns: repeat(3, 1fr); font-size: 0.4em; color: #777; line-height: 1.2; }
+.marks span:empty::before { content: "\00a0"; }
+#show-candidates:not(:checked) ~ table .marks { visibility: hidden; }
+</style>
+</head>
+<body>
+<input type="checkbox" id="show-candidates" checked>
+<label for="show-candidates">Show candidates</label>
+<table>
+{{- range .Rows}}
+<tr>
+{{- range .}}
+{{- if .Value}}
+<td class="{{if .Given}}given{{else}}placed{{end}}{{.Box}}">{{.Value}}</td>
+{{- else}}
+<td class="empty{{.Box}}"><div class="marks">{{range .Marks}}<span>{{if .}}{{.}}{{end}}</span>{{end}}</div></td>
+{{- end}}
+{{- end}}
+</tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+// RenderHTML writes a self-contained HTML page of the grid: givens in
+// bold, values placed by the solver in blue, and the options of empty
+// cells as pencil marks which a checkbox shows or hides.
+func (g *Grid) RenderHTML(w io.Writer) error {
+	var data struct {
+		Rows [9][9]htmlCell
+	}
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			var cell = htmlCell{Value: g.cells[row][col], Given: g.givens[row][col]}
+			if (col%3 == 2 && col < 8) {
+				cell.Box += " right"
+			}
+			if (row%3 == 2 && row < 8) {
+				cell.Box += " bottom"
+			}
+			for _, value := range g.options[row][col].values() {
+				cell.Marks[value-1] = value
+			}
+			data.Rows[row][col] = cell
+		}
+	}
+	return htmlPage.Execute(w, data)
+}
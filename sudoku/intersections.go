@@ -0,0 +1,96 @@
+package sudoku
+
+// Pointing removes a value from a row or column when, in a square
+// crossing it, the value can only go in the cells shared with that row
+// or column: pointing pairs and triples.
+type Pointing struct{}
+
+func (Pointing) Name() string {
+	return "pointing"
+}
+
+func (p Pointing) Apply(g *Grid) (Progress, error) {
+	var progress Progress
+	for _, square := range houses[:9] {
+		for value := 1; value <= 9; value++ {
+			var places = g.placesOf(square, value)
+			if (len(places) < 2) {
+				continue
+			}
+			for _, line := range linesThrough(places) {
+				for _, cell := range line.Cells {
+					if (square.Contains(cell)) {
+						continue
+					}
+					g.eliminate(&progress, p.Name(), cell, value, square.String(), "")
+				}
+			}
+		}
+	}
+	return progress, nil
+}
+
+// Claiming removes a value from a square when, in a row or column
+// crossing it, the value can only go in the cells shared with that
+// square: line-box reduction, the converse of Pointing.
+type Claiming struct{}
+
+func (Claiming) Name() string {
+	return "claiming"
+}
+
+func (c Claiming) Apply(g *Grid) (Progress, error) {
+	var progress Progress
+	for _, line := range houses[9:] {
+		for value := 1; value <= 9; value++ {
+			var places = g.placesOf(line, value)
+			if (len(places) < 2) {
+				continue
+			}
+			var square = getSquareFromRowCol(places[0].Row, places[0].Col)
+			var sameSquare bool = true
+			for _, cell := range places[1:] {
+				sameSquare = sameSquare && getSquareFromRowCol(cell.Row, cell.Col) == square
+			}
+			if (!sameSquare) {
+				continue
+			}
+			for _, cell := range houses[square-1].Cells {
+				if (line.Contains(cell)) {
+					continue
+				}
+				g.eliminate(&progress, c.Name(), cell, value, line.String(), "")
+			}
+		}
+	}
+	return progress, nil
+}
+
+// placesOf returns the cells of house having value as an option.
+func (g *Grid) placesOf(house House, value int) []Coord {
+	var places []Coord
+	for _, cell := range house.Cells {
+		if (g.HasCandidate(cell.Row, cell.Col, value)) {
+			places = append(places, cell)
+		}
+	}
+	return places
+}
+
+// linesThrough returns the row and the column holding all the cells,
+// when they share one.
+func linesThrough(cells []Coord) []House {
+	var sameRow, sameCol bool = true, true
+	for _, cell := range cells[1:] {
+		sameRow = sameRow && cell.Row == cells[0].Row
+		sameCol = sameCol && cell.Col == cells[0].Col
+	}
+	var lines []House
+	if (sameRow) {
+		lines = append(lines, houses[9+cells[0].Row])
+	}
+	if (sameCol) {
+		lines = append(lines, houses[18+cells[0].Col])
+	}
+	return lines
+}
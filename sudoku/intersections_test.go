@@ -0,0 +1,130 @@
+package sudoku
+
+import (
+	"testing"
+)
+
+func TestPointing(t *testing.T) {
+	var tests = []struct {
+		name       string
+		value      int
+		picture    string
+		eliminated string
+	}{
+		{
+			"pair in a row", 5, `
+				xx.xxxxxx
+				...xxxxxx
+				...xxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx`,
+			"r1c4-5 r1c5-5 r1c6-5 r1c7-5 r1c8-5 r1c9-5",
+		},
+		{
+			"pair in a column", 7, `
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxx.x.xxx
+				xxx...xxx
+				xxx.x.xxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx`,
+			"r1c5-7 r2c5-7 r3c5-7 r7c5-7 r8c5-7 r9c5-7",
+		},
+		{
+			"triple in a row", 2, `
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxx...
+				xxxxxx...
+				xxxxxxxxx`,
+			"r9c1-2 r9c2-2 r9c3-2 r9c4-2 r9c5-2 r9c6-2",
+		},
+		{
+			"places in two rows and two columns", 3, `
+				x.xxxxxxx
+				.x.xxxxxx
+				...xxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx`,
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		var eliminated = eliminations(t, Pointing{}, valueGrid(t, test.value, test.picture))
+		if (eliminated != test.eliminated) {
+			t.Errorf("%s: eliminated %s, want %s", test.name, eliminated, test.eliminated)
+		}
+	}
+}
+
+func TestClaiming(t *testing.T) {
+	var tests = []struct {
+		name       string
+		value      int
+		picture    string
+		eliminated string
+	}{
+		{
+			"pair in a row", 4, `
+				xx.......
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx`,
+			"r2c1-4 r2c2-4 r2c3-4 r3c1-4 r3c2-4 r3c3-4",
+		},
+		{
+			"pair in a column", 9, `
+				xxxxxxxx.
+				xxxxxxxx.
+				xxxxxxxx.
+				xxxxxxxx.
+				xxxxxxxx.
+				xxxxxxxx.
+				xxxxxxxxx
+				xxxxxxxx.
+				xxxxxxxxx`,
+			"r7c7-9 r7c8-9 r8c7-9 r8c8-9 r9c7-9 r9c8-9",
+		},
+		{
+			"places in two squares", 6, `
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				x..x.....
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx`,
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		var eliminated = eliminations(t, Claiming{}, valueGrid(t, test.value, test.picture))
+		if (eliminated != test.eliminated) {
+			t.Errorf("%s: eliminated %s, want %s", test.name, eliminated, test.eliminated)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package sudoku
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// jsonGrid is the JSON representation of a Grid.
+type jsonGrid struct {
+	Grid       string       `json:"grid"`
+	Candidates *[9][9][]int `json:"candidates,omitempty"`
+}
+
+// MarshalJSON encodes the grid as its 81 digits string, with the
+// options of the empty cells when they have been listed:
+//   {"grid": "0060003...", "candidates": [[[1, 2], [], ...], ...]}
+func (g Grid) MarshalJSON() ([]byte, error) {
+	var out = jsonGrid{Grid: g.String()}
+	if (g.listed) {
+		var candidates = g.Options()
+		out.Candidates = jsonCandidates(&candidates)
+	}
+	return json.Marshal(out)
+}
+
+// jsonCandidates returns a copy of candidates where the cells without
+// any, such as filled cells, have an empty list, encoded as [] rather
+// than null.
+func jsonCandidates(candidates *[9][9][]int) *[9][9][]int {
+	if (candidates == nil) {
+		return nil
+	}
+	var out = *candidates
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (out[row][col] == nil) {
+				out[row][col] = []int{}
+			}
+		}
+	}
+	return &out
+}
+
+// UnmarshalJSON decodes a grid encoded by MarshalJSON. Without
+// candidates, the options of the grid are left to be listed.
+func (g *Grid) UnmarshalJSON(data []byte) error {
+	var in jsonGrid
+	err := json.Unmarshal(data, &in)
+	if (err != nil) {
+		return err
+	}
+
+	err = g.UnmarshalText([]byte(in.Grid))
+	if (err != nil) {
+		return err
+	}
+	if (in.Candidates != nil) {
+		return g.SetOptions(*in.Candidates)
+	}
+	return nil
+}
+
+// jsonSolveReport is the JSON representation of a SolveReport.
+type jsonSolveReport struct {
+	Solved     bool             `json:"solved"`
+	Passes     int              `json:"passes"`
+	Techniques []string         `json:"techniques"`
+	Steps      []jsonStepReport `json:"steps"`
+	Stats      []jsonStats      `json:"stats"`
+	EmptyCells int              `json:"empty_cells"`
+	ElapsedMs  float64          `json:"elapsed_ms"`
+}
+
+type jsonStepReport struct {
+	Pass       int    `json:"pass"`
+	Technique  string `json:"technique"`
+	Placed     int    `json:"placed"`
+	Eliminated int    `json:"eliminated"`
+}
+
+// jsonStats is the JSON representation of a TechniqueStats. Reports
+// decode without them, as they follow from the steps.
+type jsonStats struct {
+	Technique  string `json:"technique"`
+	Uses       int    `json:"uses"`
+	Placed     int    `json:"placed"`
+	Eliminated int    `json:"eliminated"`
+}
+
+// newJSONStats returns the tally of the report, empty rather than nil.
+func newJSONStats(r SolveReport) []jsonStats {
+	var stats = []jsonStats{}
+	for _, tally := range r.Stats() {
+		stats = append(stats, jsonStats(tally))
+	}
+	return stats
+}
+
+// MarshalJSON encodes the report, with the elapsed time in
+// milliseconds.
+func (r SolveReport) MarshalJSON() ([]byte, error) {
+	var out = jsonSolveReport{
+		Solved:     r.Solved,
+		Passes:     r.Passes,
+		Techniques: r.Techniques,
+		Steps:      []jsonStepReport{},
+		Stats:      newJSONStats(r),
+		EmptyCells: r.EmptyCells,
+		ElapsedMs:  float64(r.Elapsed) / float64(time.Millisecond),
+	}
+	if (out.Techniques == nil) {
+		out.Techniques = []string{}
+	}
+	for _, step := range r.Steps {
+		out.Steps = append(out.Steps, jsonStepReport(step))
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes a report encoded by MarshalJSON.
+func (r *SolveReport) UnmarshalJSON(data []byte) error {
+	var in jsonSolveReport
+	err := json.Unmarshal(data, &in)
+	if (err != nil) {
+		return err
+	}
+
+	*r = SolveReport{
+		Solved:     in.Solved,
+		Passes:     in.Passes,
+		Techniques: in.Techniques,
+		EmptyCells: in.EmptyCells,
+		Elapsed:    time.Duration(in.ElapsedMs * float64(time.Millisecond)),
+	}
+	for _, step := range in.Steps {
+		r.Steps = append(r.Steps, StepReport(step))
+	}
+	return nil
+}
+
+// jsonResult is the JSON representation of a solved puzzle, see
+// WriteResultJSON.
+type jsonResult struct {
+	jsonPuzzle
+	Solved     bool        `json:"solved"`
+	Unsolved   int         `json:"unsolved"`
+	Techniques []string    `json:"techniques"`
+	Stats      []jsonStats `json:"stats"`
+	Passes     int         `json:"passes"`
+	ElapsedMs  float64     `json:"elapsed_ms"`
+}
+
+// WriteResultJSON writes the outcome of solving p as a JSON object: the
+// clues and metadata of p, its solution as far as the solver went, and
+// the number of cells left unsolved, the techniques applied and the
+// time it took, from report:
+//   {"grid": "0060003...", "solution": "2864753...", "solved": true,
+//    "unsolved": 0, "techniques": ["naked-single"], "stats": [{
+//    "technique": "naked-single", "uses": 5, "placed": 53,
+//    "eliminated": 0}], "passes": 5, "elapsed_ms": 0.2}
+func WriteResultJSON(w io.Writer, p Puzzle, report SolveReport) error {
+	var out = jsonResult{
+		jsonPuzzle: newJSONPuzzle(p),
+		Solved:     report.Solved,
+		Unsolved:   report.EmptyCells,
+		Techniques: report.Techniques,
+		Stats:      newJSONStats(report),
+		Passes:     report.Passes,
+		ElapsedMs:  float64(report.Elapsed) / float64(time.Millisecond),
+	}
+	if (out.Solution == "") {
+		out.Solution = CellsString(p.Solution, '0')
+	}
+	if (out.Techniques == nil) {
+		out.Techniques = []string{}
+	}
+
+	var encoder = json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
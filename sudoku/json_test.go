@@ -0,0 +1,105 @@
+package sudoku
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+const jsonTestPuzzle = "004209000900000020000367000500000710100080903600903000070000501000001040800000000"
+
+func TestGridJSON(t *testing.T) {
+	g, err := Parse(jsonTestPuzzle)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	g.ListOptions()
+	data, err := json.Marshal(g)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	var decoded Grid
+	err = json.Unmarshal(data, &decoded)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	if (decoded.String() != g.String()) {
+		t.Errorf("got %s, want %s", decoded.String(), g.String())
+	}
+	if (fmt.Sprint(decoded.Options()) != fmt.Sprint(g.Options())) {
+		t.Errorf("got candidates %v, want %v", decoded.Options(), g.Options())
+	}
+}
+
+func TestSolveReportJSON(t *testing.T) {
+	g, err := Parse(jsonTestPuzzle)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	g.ListOptions()
+	report, err := NewSolver().Solve(g)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(report)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	var decoded SolveReport
+	err = json.Unmarshal(data, &decoded)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	if (decoded.Solved != report.Solved || decoded.Passes != report.Passes || decoded.EmptyCells != report.EmptyCells) {
+		t.Errorf("got %+v, want %+v", decoded, report)
+	}
+	if (!slices.Equal(decoded.Techniques, report.Techniques) || !slices.Equal(decoded.Steps, report.Steps)) {
+		t.Errorf("got techniques %v and steps %v, want %v and %v", decoded.Techniques, decoded.Steps, report.Techniques, report.Steps)
+	}
+	if ((decoded.Elapsed - report.Elapsed).Abs() > time.Microsecond) {
+		t.Errorf("got %v elapsed, want %v", decoded.Elapsed, report.Elapsed)
+	}
+}
+
+func TestGridMarshalJSONValue(t *testing.T) {
+	g, err := Parse(jsonTestPuzzle)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	g.OnEvent = func(Event) {}
+	g.ListOptions()
+	solved, err := Solve(*g)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+
+	var holder = struct {
+		Grid Grid `json:"grid"`
+	}{solved}
+	data, err := json.Marshal(holder)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Grid Grid `json:"grid"`
+	}
+	err = json.Unmarshal(data, &decoded)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	if (decoded.Grid.String() != solved.String()) {
+		t.Errorf("got %s, want %s", decoded.Grid.String(), solved.String())
+	}
+}
+
+func TestGridUnmarshalJSONCandidates(t *testing.T) {
+	var candidates = `[[[10,11],[],[],[],[],[],[],[],[]],[],[],[],[],[],[],[],[]]`
+	var g Grid
+	err := json.Unmarshal([]byte(`{"grid": "`+strings.Repeat("0", 81)+`", "candidates": `+candidates+`}`), &g)
+	if (err == nil || !strings.Contains(err.Error(), "r1c1")) {
+		t.Errorf("got %v, want an error naming r1c1", err)
+	}
+}
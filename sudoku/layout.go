@@ -0,0 +1,93 @@
+package sudoku
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var ansiEscape = regexp.MustCompile("\033\\[[0-9;]*m")
+
+// ParseLayout reads a grid written in any of these layouts:
+//   - the 81 values on one line,
+//   - nine lines of nine values,
+//   - a drawing with | + - borders, like the output of Render.
+// Values are digits, with 0, . or _ for empty cells. In drawings, blank
+// cells are empty as well, and lines without | are ignored.
+func ParseLayout(r io.Reader) (*Grid, error) {
+	var lines []string
+	var drawn bool = false
+	var scanner = bufio.NewScanner(r)
+	for scanner.Scan() {
+		var line = ansiEscape.ReplaceAllString(scanner.Text(), "")
+		drawn = drawn || strings.Contains(line, "|")
+		lines = append(lines, line)
+	}
+	if (scanner.Err() != nil) {
+		return nil, scanner.Err()
+	}
+
+	var values strings.Builder
+	for _, line := range lines {
+		if (!drawn) {
+			values.WriteString(strings.Join(strings.Fields(line), ""))
+			continue
+		}
+
+		var first, last int = strings.Index(line, "|"), strings.LastIndex(line, "|")
+		if (first == last) {
+			continue
+		}
+		var segments = strings.Split(line[first+1:last], "|")
+		switch len(segments) {
+		case 9:
+			for _, segment := range segments {
+				values.WriteByte(drawnCell(strings.TrimSpace(segment)))
+			}
+		case 3:
+			for _, segment := range segments {
+				values.WriteString(drawnSquareRow(segment))
+			}
+		default:
+			return nil, fmt.Errorf("not a valid grid, cannot read row %q", line)
+		}
+	}
+
+	cells, err := ParseCells(values.String())
+	if (err != nil) {
+		return nil, err
+	}
+	return NewGrid(cells), nil
+}
+
+// drawnCell returns the value of a drawn cell, . if it is empty or
+// holds a marker.
+func drawnCell(cell string) byte {
+	if (len(cell) == 1 && cell[0] >= '0' && cell[0] <= '9') {
+		return cell[0]
+	}
+	return '.'
+}
+
+// drawnSquareRow returns the three values of a row of a square drawn
+// without | between cells, such as "| 1   3 |".
+func drawnSquareRow(segment string) string {
+	var fields = strings.Fields(segment)
+	if (len(fields) == 3) {
+		return string([]byte{drawnCell(fields[0]), drawnCell(fields[1]), drawnCell(fields[2])})
+	}
+	if (len(fields) == 1 && len(fields[0]) == 3) {
+		return fields[0]
+	}
+
+	// blank cells: values are evenly spaced in the segment
+	var runes = []rune(segment)
+	var values []byte
+	for k := 0; k < 3; k++ {
+		var pos int = len(runes) * (2*k + 1) / 6
+		values = append(values, drawnCell(strings.TrimSpace(string(runes[pos]))))
+	}
+	return string(values)
+}
@@ -0,0 +1,76 @@
+package sudoku
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const layoutPuzzle = "004209000900000020000367000500000710100080903600903000070000501000001040800000000"
+
+func TestParseLayout(t *testing.T) {
+	var tests = []struct {
+		name string
+		text string
+	}{
+		{"one line", "..42.9...9......2....367...5.....71.1...8.9.36..9.3....7....5.1.....1.4.8........"},
+		{"nine lines", `
+			0 0 4 2 0 9 0 0 0
+			9 0 0 0 0 0 0 2 0
+			0 0 0 3 6 7 0 0 0
+			5 0 0 0 0 0 7 1 0
+			1 0 0 0 8 0 9 0 3
+			6 0 0 9 0 3 0 0 0
+			0 7 0 0 0 0 5 0 1
+			0 0 0 0 0 1 0 4 0
+			8 0 0 0 0 0 0 0 0`,
+		},
+		{"drawing with blank cells", `
+			+-------+-------+-------+
+			|     4 | 2   9 |       |
+			| 9     |       |   2   |
+			|       | 3 6 7 |       |
+			+-------+-------+-------+
+			| 5     |       | 7 1   |
+			| 1     |   8   | 9   3 |
+			| 6     | 9   3 |       |
+			+-------+-------+-------+
+			|   7   |       | 5   1 |
+			|       |     1 |   4   |
+			| 8     |       |       |
+			+-------+-------+-------+`,
+		},
+	}
+
+	g, err := Parse(layoutPuzzle)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	defer SetStyle(Styles["classic"])
+	for _, name := range []string{"compact", "classic", "spacious"} {
+		SetStyle(Styles[name])
+		var buf bytes.Buffer
+		err := g.Render(&buf, FormatGrid)
+		if (err != nil) {
+			t.Fatal(err)
+		}
+		tests = append(tests, struct {
+			name string
+			text string
+		}{"rendered in the " + name + " style", buf.String()})
+	}
+
+	for _, test := range tests {
+		g, err := ParseLayout(strings.NewReader(test.text))
+		if (err != nil) {
+			t.Errorf("%s: %v", test.name, err)
+		} else if (g.String() != layoutPuzzle) {
+			t.Errorf("%s: got %s, want %s", test.name, g.String(), layoutPuzzle)
+		}
+	}
+
+	_, err = ParseLayout(strings.NewReader(layoutPuzzle[:79]))
+	if (err == nil) {
+		t.Error("79 values: got no error")
+	}
+}
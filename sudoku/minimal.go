@@ -0,0 +1,40 @@
+package sudoku
+
+// RedundantClues returns the givens of a puzzle with a single solution
+// which can each be removed, the puzzle keeping a single solution.
+// Removing one may make others needed, so they cannot all go at once.
+// It returns ErrNoSolution or a *NotUniqueError when the puzzle itself
+// does not have a single solution.
+func (g *Grid) RedundantClues() ([]Coord, error) {
+	var solutions = g.AllSolutions(2)
+	if (len(solutions) == 0) {
+		return nil, ErrNoSolution
+	}
+	if (len(solutions) > 1) {
+		return nil, &NotUniqueError{solutions}
+	}
+
+	var redundant []Coord
+	var cells = g.cells
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (!g.givens[row][col] || cells[row][col] == 0) {
+				continue
+			}
+			var value = cells[row][col]
+			cells[row][col] = 0
+			if (countSolutions(cells, 2) == 1) {
+				redundant = append(redundant, Coord{row, col})
+			}
+			cells[row][col] = value
+		}
+	}
+	return redundant, nil
+}
+
+// IsMinimal returns true if the puzzle has a single solution, which
+// every given is needed for.
+func (g *Grid) IsMinimal() bool {
+	redundant, err := g.RedundantClues()
+	return err == nil && len(redundant) == 0
+}
@@ -0,0 +1,178 @@
+package sudoku
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PDFOptions tell how WritePDF lays out the puzzles.
+type PDFOptions struct {
+	// PerPage is the number of puzzles on each page: 1, 2, 4 or 6.
+	PerPage int
+
+	// Solutions adds pages with the solution of every puzzle at the
+	// end of the document.
+	Solutions bool
+}
+
+// pdfLayouts gives the columns and rows of grids for each number of
+// puzzles per page.
+var pdfLayouts = map[int][2]int{
+	1: {1, 1},
+	2: {1, 2},
+	4: {2, 2},
+	6: {2, 3},
+}
+
+// Size of the A4 pages and of their margins, in points.
+const (
+	pdfWidth  = 595
+	pdfHeight = 842
+	pdfMargin = 50
+)
+
+// WritePDF writes a printable PDF document of the puzzles, as A4 pages
+// holding opts.PerPage puzzles each. Solutions are searched for when
+// the puzzles do not carry them, and a puzzle without solution is an
+// error: the answer key never shows a partial grid.
+func WritePDF(w io.Writer, puzzles []Puzzle, opts PDFOptions) error {
+	if (len(puzzles) == 0) {
+		return errors.New("pdf: no puzzle to write")
+	}
+	layout, ok := pdfLayouts[opts.PerPage]
+	if (!ok) {
+		return fmt.Errorf("pdf: cannot lay out %d puzzles per page, use 1, 2, 4 or 6", opts.PerPage)
+	}
+
+	var pages [][]byte
+	var addPages = func(title string, grids []*Grid) {
+		for first := 0; first < len(grids); first += opts.PerPage {
+			var content bytes.Buffer
+			for i := first; i < len(grids) && i < first+opts.PerPage; i++ {
+				var heading = fmt.Sprintf("%s %d", title, i+1)
+				if (puzzles[i].Title != "") {
+					heading += ": " + puzzles[i].Title
+				}
+				pdfDrawGrid(&content, grids[i], heading, layout, i-first)
+			}
+			pages = append(pages, content.Bytes())
+		}
+	}
+
+	var grids []*Grid
+	for _, p := range puzzles {
+		grids = append(grids, NewGrid(p.Cells))
+	}
+	addPages("Puzzle", grids)
+
+	if (opts.Solutions) {
+		var solutions []*Grid
+		for i, p := range puzzles {
+			var g = NewGrid(p.Cells)
+			if (p.Solution != [9][9]int{}) {
+				g.cells = p.Solution
+			} else {
+				var found = g.AllSolutions(1)
+				if (len(found) == 0) {
+					return fmt.Errorf("pdf: puzzle %d has no solution", i+1)
+				}
+				g = &found[0]
+			}
+			solutions = append(solutions, g)
+		}
+		addPages("Solution", solutions)
+	}
+
+	return writePDFDocument(w, pages)
+}
+
+// pdfDrawGrid writes the drawing operators of g, with a title above
+// it, at position index of the page layout (columns, rows).
+func pdfDrawGrid(buf *bytes.Buffer, g *Grid, title string, layout [2]int, index int) {
+	var slotWidth = float64(pdfWidth-2*pdfMargin) / float64(layout[0])
+	var slotHeight = float64(pdfHeight-2*pdfMargin) / float64(layout[1])
+	var side = min(slotWidth, slotHeight-30) * 0.9
+	var cell = side / 9
+
+	// top left corner of the grid, PDF coordinates go upward
+	var x = pdfMargin + float64(index%layout[0])*slotWidth + (slotWidth-side)/2
+	var y = pdfHeight - pdfMargin - float64(index/layout[0])*slotHeight - 30
+
+	fmt.Fprintf(buf, "BT /F1 12 Tf %.2f %.2f Td (%s) Tj ET\n", x, y+10, pdfEscape(title))
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (g.cells[row][col] == 0) {
+				continue
+			}
+			var font = "F1"
+			if (g.givens[row][col]) {
+				font = "F2"
+			}
+			// digits of Helvetica are 0.556 em wide, and 0.718 em high
+			var size = cell * 0.6
+			var digitX = x + (float64(col)+0.5)*cell - 0.278*size
+			var digitY = y - (float64(row)+0.5)*cell - 0.359*size
+			fmt.Fprintf(buf, "BT /%s %.2f Tf %.2f %.2f Td (%d) Tj ET\n", font, size, digitX, digitY, g.cells[row][col])
+		}
+	}
+
+	for i := 0; i <= 9; i++ {
+		var width = 0.5
+		if (i%3 == 0) {
+			width = 2
+		}
+		var pos = float64(i) * cell
+		fmt.Fprintf(buf, "%.1f w %.2f %.2f m %.2f %.2f l S\n", width, x+pos, y, x+pos, y-side)
+		fmt.Fprintf(buf, "%.1f w %.2f %.2f m %.2f %.2f l S\n", width, x, y-pos, x+side, y-pos)
+	}
+}
+
+// pdfEscape escapes the characters special in PDF strings.
+func pdfEscape(str string) string {
+	return strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`).Replace(str)
+}
+
+// writePDFDocument writes a PDF file made of the given page contents,
+// using the standard Helvetica fonts which need no embedding.
+func writePDFDocument(w io.Writer, pages [][]byte) error {
+	var buf bytes.Buffer
+	var offsets []int
+	var addObject = func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	addObject("<< /Type /Catalog /Pages 2 0 R >>")
+	var kids []string
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 5+2*i))
+	}
+	addObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	addObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>")
+	addObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold >>")
+	for i, content := range pages {
+		addObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfWidth, pdfHeight, 6+2*i))
+
+		var compressed bytes.Buffer
+		var zw = zlib.NewWriter(&compressed)
+		zw.Write(content)
+		zw.Close()
+		addObject(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.String()))
+	}
+
+	var xref = buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,34 @@
+package sudoku
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWritePDF(t *testing.T) {
+	cells, err := ParseCells(concurrencyPuzzles[0].puzzle)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	err = WritePDF(&buf, []Puzzle{{Cells: cells}}, PDFOptions{PerPage: 1, Solutions: true})
+	if (err != nil || !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-"))) {
+		t.Errorf("wrote %d bytes, %v", buf.Len(), err)
+	}
+
+	// the answer key holds solutions only, not the grid of a solver
+	// stopped on a contradiction
+	invalid, err := ParseCells("11" + concurrencyPuzzles[0].puzzle[2:])
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	err = WritePDF(&buf, []Puzzle{{Cells: cells}, {Cells: invalid}}, PDFOptions{PerPage: 2, Solutions: true})
+	if (err == nil || !strings.Contains(err.Error(), "puzzle 2")) {
+		t.Errorf("got error %v, want one about puzzle 2", err)
+	}
+	err = WritePDF(&buf, []Puzzle{{Cells: invalid}}, PDFOptions{PerPage: 1})
+	if (err != nil) {
+		t.Errorf("without solutions: %v", err)
+	}
+}
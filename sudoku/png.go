@@ -0,0 +1,169 @@
+package sudoku
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+)
+
+// PNGOptions tell how RenderPNG draws the grid.
+type PNGOptions struct {
+	// Size is the width and height of the image, in pixels.
+	Size int
+
+	// DPI is the resolution stored in the image for print workflows,
+	// 0 to leave it out.
+	DPI int
+}
+
+// pngFont holds a 5x7 bitmap of each digit, for images drawn without
+// any font installed.
+var pngFont = [10][7]string{
+	{},
+	{"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	{".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	{"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###."},
+	{"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	{"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	{"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	{"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	{".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	{".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##.."},
+}
+
+// Colors of the PNG drawing, matching the SVG ones.
+var (
+	pngGivenColor  = color.RGBA{0x00, 0x00, 0x00, 0xff}
+	pngPlacedColor = color.RGBA{0x1a, 0x5f, 0xb4, 0xff}
+	pngMarkColor   = color.RGBA{0x77, 0x77, 0x77, 0xff}
+	pngThinColor   = color.RGBA{0x99, 0x99, 0x99, 0xff}
+
+	pngHighlightColor = color.RGBA{0xff, 0xe8, 0x80, 0xff}
+)
+
+// RenderPNG writes a PNG image of the grid, drawn as by RenderSVG:
+// thick lines around squares, givens in bold, values placed by the
+// solver in blue and options of empty cells as pencil marks.
+func (g *Grid) RenderPNG(w io.Writer, opts PNGOptions) error {
+	if (opts.Size < 90) {
+		return fmt.Errorf("image size %d is too small, use at least 90 pixels", opts.Size)
+	}
+	if (opts.DPI < 0) {
+		return fmt.Errorf("invalid DPI %d", opts.DPI)
+	}
+
+	var img = g.drawImage(opts.Size, nil)
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	if (err != nil) {
+		return err
+	}
+	var data = buf.Bytes()
+	if (opts.DPI > 0) {
+		data = withPNGResolution(data, opts.DPI)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// drawImage draws the grid as RenderPNG does, on a square image of size
+// pixels, with a yellow background behind the highlighted cells.
+func (g *Grid) drawImage(size int, highlight []Coord) *image.RGBA {
+	var cell = size * 50 / 470 // same proportions as the SVG
+	var margin = (size - 9*cell) / 2
+	var img = image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	for _, c := range highlight {
+		var x = margin + c.Col*cell
+		var y = margin + c.Row*cell
+		draw.Draw(img, image.Rect(x, y, x+cell, y+cell), image.NewUniform(pngHighlightColor), image.Point{}, draw.Src)
+	}
+
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			var x = margin + col*cell
+			var y = margin + row*cell
+			if (g.cells[row][col] != 0) {
+				var fill, bold = pngPlacedColor, false
+				if (g.givens[row][col]) {
+					fill, bold = pngGivenColor, true
+				}
+				drawDigit(img, g.cells[row][col], x+cell/2, y+cell/2, cell/10, fill, bold)
+				continue
+			}
+			for _, value := range g.options[row][col].values() {
+				var markX = x + (value-1)%3*cell/3 + cell/6
+				var markY = y + (value-1)/3*cell/3 + cell/6
+				drawDigit(img, value, markX, markY, max(cell/30, 1), pngMarkColor, false)
+			}
+		}
+	}
+
+	// thin lines first, so that the thick ones are drawn over them
+	var thick = max(cell/16, 2)
+	var length = 9*cell + thick
+	for _, isThick := range []bool{false, true} {
+		for i := 0; i <= 9; i++ {
+			if ((i%3 == 0) != isThick) {
+				continue
+			}
+			var lineColor, width = pngThinColor, 1
+			if (isThick) {
+				lineColor, width = pngGivenColor, thick
+			}
+			var from = margin + i*cell - width/2
+			var start = margin - thick/2
+			draw.Draw(img, image.Rect(from, start, from+width, start+length), image.NewUniform(lineColor), image.Point{}, draw.Src)
+			draw.Draw(img, image.Rect(start, from, start+length, from+width), image.NewUniform(lineColor), image.Point{}, draw.Src)
+		}
+	}
+
+	return img
+}
+
+// drawDigit draws value with pngFont centered on x, y, each dot of the
+// font being a square of scale pixels. Bold digits have wider dots.
+func drawDigit(img *image.RGBA, value int, x int, y int, scale int, fill color.Color, bold bool) {
+	var left = x - 5*scale/2
+	var top = y - 7*scale/2
+	var shift int = 0
+	if (bold) {
+		shift = max(scale/2, 1)
+	}
+	for dy, line := range pngFont[value] {
+		for dx, dot := range line {
+			if (dot != '#') {
+				continue
+			}
+			var dotX = left + dx*scale
+			var dotY = top + dy*scale
+			draw.Draw(img, image.Rect(dotX, dotY, dotX+scale+shift, dotY+scale), image.NewUniform(fill), image.Point{}, draw.Src)
+		}
+	}
+}
+
+// withPNGResolution inserts a pHYs chunk telling the resolution in
+// dots per inch right after the IHDR chunk of a PNG file.
+func withPNGResolution(data []byte, dpi int) []byte {
+	const ihdrEnd = 8 + 4 + 4 + 13 + 4 // signature, then length, type, data and CRC
+	var perMeter = uint32(math.Round(float64(dpi) / 0.0254))
+
+	var chunk bytes.Buffer
+	binary.Write(&chunk, binary.BigEndian, uint32(9))
+	chunk.WriteString("pHYs")
+	binary.Write(&chunk, binary.BigEndian, perMeter)
+	binary.Write(&chunk, binary.BigEndian, perMeter)
+	chunk.WriteByte(1) // unit is the meter
+	binary.Write(&chunk, binary.BigEndian, crc32.ChecksumIEEE(chunk.Bytes()[4:]))
+
+	var out = append([]byte{}, data[:ihdrEnd]...)
+	out = append(out, chunk.Bytes()...)
+	return append(out, data[ihdrEnd:]...)
+}
@@ -0,0 +1,127 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"math"
+)
+
+// qrQuietZone is the light margin around QR codes, in modules.
+const qrQuietZone = 4
+
+// WriteQR writes a PNG image of the QR code of the grid's 81 digits,
+// with scale pixels per module. Scanned by a phone, the code reads as
+// the digits of String.
+func (g *Grid) WriteQR(w io.Writer, scale int) error {
+	if (scale < 1) {
+		return fmt.Errorf("invalid QR code scale %d", scale)
+	}
+	q, err := encodeQR(g.String())
+	if (err != nil) {
+		return err
+	}
+
+	var side = (q.size + 2*qrQuietZone) * scale
+	var img = image.NewGray(image.Rect(0, 0, side, side))
+	for y := 0; y < side; y++ {
+		for x := 0; x < side; x++ {
+			var row = y/scale - qrQuietZone
+			var col = x/scale - qrQuietZone
+			var dark = row >= 0 && row < q.size && col >= 0 && col < q.size && q.modules[row][col]
+			if (dark) {
+				img.SetGray(x, y, color.Gray{0})
+			} else {
+				img.SetGray(x, y, color.Gray{255})
+			}
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// ReadQR reads a grid from an image of its QR code, such as written by
+// WriteQR. The image may be a PNG, GIF or JPEG, and must be clean: the
+// code upright, not skewed, with a light margin around it.
+func ReadQR(r io.Reader) (*Grid, error) {
+	img, _, err := image.Decode(r)
+	if (err != nil) {
+		return nil, err
+	}
+	q, err := sampleQR(img)
+	if (err != nil) {
+		return nil, err
+	}
+	digits, err := decodeQR(q)
+	if (err != nil) {
+		return nil, err
+	}
+	return Parse(digits)
+}
+
+// sampleQR finds the QR code in the image, from the bounds of its dark
+// pixels, and reads its modules at their centers.
+func sampleQR(img image.Image) (*qrSymbol, error) {
+	var bounds = img.Bounds()
+	var isDark = func(x int, y int) bool {
+		gray := color.GrayModel.Convert(img.At(x, y)).(color.Gray)
+		return gray.Y < 128
+	}
+
+	var left, top, right, bottom = bounds.Max.X, bounds.Max.Y, bounds.Min.X - 1, bounds.Min.Y - 1
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if (isDark(x, y)) {
+				left, right = min(left, x), max(right, x)
+				top, bottom = min(top, y), max(bottom, y)
+			}
+		}
+	}
+	if (right < left) {
+		return nil, errQRNotFound
+	}
+
+	// the top left finder pattern starts with 7 dark modules
+	var run int = 0
+	for x := left; x <= right && isDark(x, top); x++ {
+		run++
+	}
+	var module = float64(run) / 7
+	var size = int(math.Round(float64(right-left+1) / module))
+	var version = (size - 17) / 4
+	if ((size-17)%4 != 0 || version < 1 || version > qrMaxVersion) {
+		return nil, fmt.Errorf("%w: %d modules wide, expected a version 1 to %d code", errQRNotFound, size, qrMaxVersion)
+	}
+	module = float64(right-left+1) / float64(size)
+
+	var q = newQRSymbol(version)
+	for row := 0; row < size; row++ {
+		for col := 0; col < size; col++ {
+			var x = left + int((float64(col)+0.5)*module)
+			var y = top + int((float64(row)+0.5)*module)
+			q.modules[row][col] = isDark(x, y)
+		}
+	}
+	return q, nil
+}
+
+// readQRPuzzles reads the puzzle of a QR code image, see ReadQR.
+func readQRPuzzles(r io.Reader) ([]Puzzle, error) {
+	g, err := ReadQR(r)
+	if (err != nil) {
+		return nil, fmt.Errorf("qr: %w", err)
+	}
+	return []Puzzle{{Cells: g.Cells()}}, nil
+}
+
+// writeQRPuzzles writes the QR code image of a single puzzle.
+func writeQRPuzzles(w io.Writer, puzzles []Puzzle) error {
+	if (len(puzzles) != 1) {
+		return errors.New("qr: an image holds exactly one puzzle")
+	}
+	return NewGrid(puzzles[0].Cells).WriteQR(w, 8)
+}
@@ -0,0 +1,69 @@
+package sudoku
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+const qrPuzzle = "004209000900000020000367000500000710100080903600903000070000501000001040800000000"
+
+func TestQR(t *testing.T) {
+	cells, err := ParseCells(qrPuzzle)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	for _, scale := range []int{1, 3, 8} {
+		var code bytes.Buffer
+		err := NewGrid(cells).WriteQR(&code, scale)
+		if (err != nil) {
+			t.Fatalf("scale %d: %v", scale, err)
+		}
+		g, err := ReadQR(&code)
+		if (err != nil) {
+			t.Fatalf("scale %d: %v", scale, err)
+		}
+		if (g.Cells() != cells) {
+			t.Errorf("scale %d: read %s, want %s", scale, CellsString(g.Cells(), '0'), qrPuzzle)
+		}
+	}
+
+	var buf bytes.Buffer
+	err = WritePuzzles(&buf, "qr", []Puzzle{{Cells: cells}})
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	puzzles, err := ReadPuzzles(&buf, "qr")
+	if (err != nil || len(puzzles) != 1 || puzzles[0].Cells != cells) {
+		t.Errorf("read puzzles %v, %v from the qr format", puzzles, err)
+	}
+	err = WritePuzzles(&buf, "qr", []Puzzle{{Cells: cells}, {Cells: cells}})
+	if (err == nil) {
+		t.Errorf("wrote two puzzles in one image")
+	}
+	err = NewGrid(cells).WriteQR(&buf, 0)
+	if (err == nil) {
+		t.Errorf("wrote a QR code at scale 0")
+	}
+}
+
+func TestReadQRBlank(t *testing.T) {
+	var blank = image.NewGray(image.Rect(0, 0, 100, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 100; x++ {
+			blank.SetGray(x, y, color.Gray{255})
+		}
+	}
+	var buf bytes.Buffer
+	err := png.Encode(&buf, blank)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	_, err = ReadQR(&buf)
+	if (!errors.Is(err, errQRNotFound)) {
+		t.Errorf("got error %v, want %v", err, errQRNotFound)
+	}
+}
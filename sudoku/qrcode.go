@@ -0,0 +1,484 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+)
+
+// This file holds a small QR code codec, enough to share puzzles: it
+// encodes and decodes strings of digits (numeric mode) in QR codes of
+// versions 1 to 6 with error correction level M. An 81 digits puzzle
+// fits in a version 3 code.
+
+// qrBlocks gives, for each version, the number of error correction
+// blocks and of data and error correction codewords per block at
+// level M. All blocks have the same size up to version 6.
+var qrBlocks = [7]struct {
+	count int
+	data  int
+	ec    int
+}{
+	{},
+	{1, 16, 10},
+	{1, 28, 16},
+	{1, 44, 26},
+	{2, 32, 18},
+	{2, 43, 24},
+	{4, 27, 16},
+}
+
+// qrMaxVersion is the largest version the codec handles. Larger codes
+// need version information blocks.
+const qrMaxVersion = 6
+
+// errQRNotFound is returned when an image holds no readable QR code.
+var errQRNotFound = errors.New("no readable QR code found")
+
+// qrSymbol is the grid of modules of a QR code, true for dark.
+type qrSymbol struct {
+	version  int
+	size     int
+	modules  [][]bool
+	function [][]bool // finder, timing, alignment and format modules
+}
+
+// newQRSymbol returns a symbol of the given version with its function
+// patterns drawn, and its format modules reserved.
+func newQRSymbol(version int) *qrSymbol {
+	var q = &qrSymbol{version: version, size: 17 + 4*version}
+	q.modules = make([][]bool, q.size)
+	q.function = make([][]bool, q.size)
+	for y := 0; y < q.size; y++ {
+		q.modules[y] = make([]bool, q.size)
+		q.function[y] = make([]bool, q.size)
+	}
+
+	for i := 0; i < q.size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+	q.drawFinder(3, 3)
+	q.drawFinder(q.size-4, 3)
+	q.drawFinder(3, q.size-4)
+	if (version > 1) {
+		// versions 2 to 6 have one alignment pattern
+		var pos = q.size - 7
+		for dy := -2; dy <= 2; dy++ {
+			for dx := -2; dx <= 2; dx++ {
+				q.setFunction(pos+dx, pos+dy, max(abs(dx), abs(dy)) != 1)
+			}
+		}
+	}
+	q.drawFormat(0)
+	return q
+}
+
+func abs(n int) int {
+	if (n < 0) {
+		return -n
+	}
+	return n
+}
+
+// setFunction sets a module of a function pattern.
+func (q *qrSymbol) setFunction(x int, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.function[y][x] = true
+}
+
+// drawFinder draws a finder pattern and its separator around the
+// module at x, y.
+func (q *qrSymbol) drawFinder(x int, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			if (x+dx < 0 || x+dx >= q.size || y+dy < 0 || y+dy >= q.size) {
+				continue
+			}
+			var dist = max(abs(dx), abs(dy))
+			q.setFunction(x+dx, y+dy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// qrFormatBits returns the 15 bits of format information for level M
+// and the given mask, with their BCH error correction.
+func qrFormatBits(mask int) int {
+	var data = 0<<3 | mask // level M is 00
+	var rem = data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// qrFormatPositions returns where the two copies of the format bits
+// go, bit 0 first.
+func (q *qrSymbol) qrFormatPositions() ([15]Coord, [15]Coord) {
+	var first, second [15]Coord
+	for i := 0; i < 6; i++ {
+		first[i] = Coord{i, 8}
+	}
+	first[6] = Coord{7, 8}
+	first[7] = Coord{8, 8}
+	first[8] = Coord{8, 7}
+	for i := 9; i < 15; i++ {
+		first[i] = Coord{8, 14 - i}
+	}
+	for i := 0; i < 8; i++ {
+		second[i] = Coord{8, q.size - 1 - i}
+	}
+	for i := 8; i < 15; i++ {
+		second[i] = Coord{q.size - 15 + i, 8}
+	}
+	return first, second
+}
+
+// drawFormat writes the format information of the mask.
+func (q *qrSymbol) drawFormat(mask int) {
+	var bits = qrFormatBits(mask)
+	first, second := q.qrFormatPositions()
+	for i := 0; i < 15; i++ {
+		var dark = (bits>>i)&1 != 0
+		q.setFunction(first[i].Col, first[i].Row, dark)
+		q.setFunction(second[i].Col, second[i].Row, dark)
+	}
+	q.setFunction(8, q.size-8, true) // always dark
+}
+
+// readMask reads the format information and returns the mask it
+// tells, picking the closest valid format to tolerate damage.
+func (q *qrSymbol) readMask() (int, error) {
+	first, second := q.qrFormatPositions()
+	var best, bestDist int = -1, 99
+	for _, positions := range [][15]Coord{first, second} {
+		var bits int = 0
+		for i := 0; i < 15; i++ {
+			if (q.modules[positions[i].Row][positions[i].Col]) {
+				bits |= 1 << i
+			}
+		}
+		for mask := 0; mask < 8; mask++ {
+			var dist = 0
+			for diff := bits ^ qrFormatBits(mask); diff != 0; diff >>= 1 {
+				dist += diff & 1
+			}
+			if (dist < bestDist) {
+				best, bestDist = mask, dist
+			}
+		}
+	}
+	if (bestDist > 3) {
+		return 0, fmt.Errorf("%w: format is not level M", errQRNotFound)
+	}
+	return best, nil
+}
+
+// dataPositions returns the modules holding data, in reading order:
+// two columns at a time, zigzagging up and down from the right.
+func (q *qrSymbol) dataPositions() []Coord {
+	var positions []Coord
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if (right == 6) {
+			right = 5 // skip the vertical timing pattern
+		}
+		var upward = (right+1)&2 == 0
+		for vert := 0; vert < q.size; vert++ {
+			var y = vert
+			if (upward) {
+				y = q.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				var x = right - j
+				if (!q.function[y][x]) {
+					positions = append(positions, Coord{y, x})
+				}
+			}
+		}
+	}
+	return positions
+}
+
+// qrMasked returns true if the mask flips the module at x, y.
+func qrMasked(mask int, x int, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	}
+	return ((x+y)%2+x*y%3)%2 == 0
+}
+
+// applyMask flips the data modules selected by the mask. Applying it
+// twice restores the modules.
+func (q *qrSymbol) applyMask(mask int) {
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if (!q.function[y][x] && qrMasked(mask, x, y)) {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores how hard the symbol is to scan, the lower the better.
+func (q *qrSymbol) penalty() int {
+	var score int = 0
+	var dark int = 0
+	for i := 0; i < q.size; i++ {
+		var row, col []bool
+		for j := 0; j < q.size; j++ {
+			row = append(row, q.modules[i][j])
+			col = append(col, q.modules[j][i])
+			if (q.modules[i][j]) {
+				dark++
+			}
+		}
+		for _, line := range [][]bool{row, col} {
+			// runs of five or more modules of the same color
+			var run int = 1
+			for j := 1; j <= len(line); j++ {
+				if (j < len(line) && line[j] == line[j-1]) {
+					run++
+					continue
+				}
+				if (run >= 5) {
+					score += 3 + run - 5
+				}
+				run = 1
+			}
+			// patterns looking like a finder
+			for j := 0; j+11 <= len(line); j++ {
+				var window []byte
+				for _, module := range line[j : j+11] {
+					if (module) {
+						window = append(window, '1')
+					} else {
+						window = append(window, '0')
+					}
+				}
+				if (string(window) == "10111010000" || string(window) == "00001011101") {
+					score += 40
+				}
+			}
+		}
+	}
+
+	// 2x2 blocks of the same color
+	for y := 0; y < q.size-1; y++ {
+		for x := 0; x < q.size-1; x++ {
+			var c = q.modules[y][x]
+			if (c == q.modules[y][x+1] && c == q.modules[y+1][x] && c == q.modules[y+1][x+1]) {
+				score += 3
+			}
+		}
+	}
+
+	// balance of dark and light modules, by steps of 5%
+	var total = q.size * q.size
+	score += (abs(dark*20-total*10) + total - 1) / total * 10 - 10
+	return score
+}
+
+// qrMul multiplies two elements of the Galois field GF(256) used by the
+// error correction.
+func qrMul(a int, b int) int {
+	var product int = 0
+	for i := 7; i >= 0; i-- {
+		product = (product << 1) ^ ((product >> 7) * 0x11D)
+		product ^= ((b >> i) & 1) * a
+	}
+	return product
+}
+
+// qrECC returns the Reed-Solomon error correction codewords of data.
+func qrECC(data []byte, degree int) []byte {
+	var divisor = make([]int, degree)
+	divisor[degree-1] = 1
+	var root int = 1
+	for i := 0; i < degree; i++ {
+		for j := 0; j < degree; j++ {
+			divisor[j] = qrMul(divisor[j], root)
+			if (j+1 < degree) {
+				divisor[j] ^= divisor[j+1]
+			}
+		}
+		root = qrMul(root, 2)
+	}
+
+	var result = make([]int, degree)
+	for _, b := range data {
+		var factor = int(b) ^ result[0]
+		copy(result, result[1:])
+		result[degree-1] = 0
+		for i := range result {
+			result[i] ^= qrMul(divisor[i], factor)
+		}
+	}
+
+	var ecc = make([]byte, degree)
+	for i, r := range result {
+		ecc[i] = byte(r)
+	}
+	return ecc
+}
+
+// bitBuffer accumulates bits, most significant first.
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value int, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 != 0)
+	}
+}
+
+// encodeQR returns the QR code of a string of digits, in the smallest
+// version it fits.
+func encodeQR(digits string) (*qrSymbol, error) {
+	var bits bitBuffer
+	bits.append(1, 4) // numeric mode
+	bits.append(len(digits), 10)
+	for i := 0; i < len(digits); i += 3 {
+		var group = digits[i:min(i+3, len(digits))]
+		var value int = 0
+		for _, ch := range group {
+			if (ch < '0' || ch > '9') {
+				return nil, fmt.Errorf("QR codes hold digits only, got %q", ch)
+			}
+			value = value*10 + int(ch-'0')
+		}
+		bits.append(value, 3*len(group)+1)
+	}
+
+	var version int = 1
+	for version <= qrMaxVersion && len(bits) > qrBlocks[version].count*qrBlocks[version].data*8 {
+		version++
+	}
+	if (version > qrMaxVersion) {
+		return nil, fmt.Errorf("%d digits do not fit in a QR code of version %d", len(digits), qrMaxVersion)
+	}
+	var blocks = qrBlocks[version]
+	var capacity = blocks.count * blocks.data * 8
+
+	// terminator, then pad to whole codewords with 0xEC and 0x11
+	bits.append(0, min(4, capacity-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+	var data = make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if (bit) {
+			data[i/8] |= 1 << (7 - i%8)
+		}
+	}
+
+	// interleave the blocks, data first then error correction
+	var codewords []byte
+	var eccs [][]byte
+	for b := 0; b < blocks.count; b++ {
+		eccs = append(eccs, qrECC(data[b*blocks.data:(b+1)*blocks.data], blocks.ec))
+	}
+	for i := 0; i < blocks.data; i++ {
+		for b := 0; b < blocks.count; b++ {
+			codewords = append(codewords, data[b*blocks.data+i])
+		}
+	}
+	for i := 0; i < blocks.ec; i++ {
+		for b := 0; b < blocks.count; b++ {
+			codewords = append(codewords, eccs[b][i])
+		}
+	}
+
+	var q = newQRSymbol(version)
+	for i, pos := range q.dataPositions() {
+		if (i < len(codewords)*8) {
+			q.modules[pos.Row][pos.Col] = (codewords[i/8]>>(7-i%8))&1 != 0
+		}
+	}
+
+	// keep the mask easiest to scan
+	var bestMask, bestPenalty int = 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormat(mask)
+		var penalty = q.penalty()
+		if (bestPenalty < 0 || penalty < bestPenalty) {
+			bestMask, bestPenalty = mask, penalty
+		}
+		q.applyMask(mask)
+	}
+	q.applyMask(bestMask)
+	q.drawFormat(bestMask)
+	return q, nil
+}
+
+// decodeQR reads the digits of a symbol whose modules have been
+// sampled. Error correction codewords are checked, not used to repair
+// the data.
+func decodeQR(q *qrSymbol) (string, error) {
+	mask, err := q.readMask()
+	if (err != nil) {
+		return "", err
+	}
+	q.applyMask(mask)
+	defer q.applyMask(mask)
+
+	var blocks = qrBlocks[q.version]
+	var codewords = make([]byte, blocks.count*(blocks.data+blocks.ec))
+	for i, pos := range q.dataPositions() {
+		if (i < len(codewords)*8 && q.modules[pos.Row][pos.Col]) {
+			codewords[i/8] |= 1 << (7 - i%8)
+		}
+	}
+
+	var data []byte
+	for b := 0; b < blocks.count; b++ {
+		var block, ecc []byte
+		for i := 0; i < blocks.data; i++ {
+			block = append(block, codewords[i*blocks.count+b])
+		}
+		for i := 0; i < blocks.ec; i++ {
+			ecc = append(ecc, codewords[blocks.count*blocks.data+i*blocks.count+b])
+		}
+		if (string(qrECC(block, blocks.ec)) != string(ecc)) {
+			return "", fmt.Errorf("%w: damaged data", errQRNotFound)
+		}
+		data = append(data, block...)
+	}
+
+	var pos int = 0
+	var read = func(length int) int {
+		var value int = 0
+		for i := 0; i < length; i++ {
+			var bit = (data[(pos+i)/8] >> (7 - (pos+i)%8)) & 1
+			value = value<<1 | int(bit)
+		}
+		pos += length
+		return value
+	}
+	if (read(4) != 1) {
+		return "", fmt.Errorf("%w: data is not numeric", errQRNotFound)
+	}
+	var count = read(10)
+	var length = 14 + count/3*10 + []int{0, 4, 7}[count%3]
+	if (length > len(data)*8) {
+		return "", fmt.Errorf("%w: invalid length %d", errQRNotFound, count)
+	}
+	var digits []byte
+	for len(digits) < count {
+		var group = min(3, count-len(digits))
+		digits = append(digits, []byte(fmt.Sprintf("%0*d", group, read(3*group+1)))...)
+	}
+	return string(digits), nil
+}
@@ -0,0 +1,131 @@
+package sudoku
+
+import (
+	"errors"
+	"math"
+)
+
+// Difficulty levels of a Rating, from the easiest.
+const (
+	Easy       = "easy"
+	Medium     = "medium"
+	Hard       = "hard"
+	Diabolical = "diabolical"
+)
+
+// techniqueWeights score how hard each technique is to spot by hand.
+// Techniques missing here, such as backtracking, weigh maxScore. The
+// solver applies the techniques in the order of their weight, see
+// DefaultStrategies.
+var techniqueWeights = map[string]float64{
+	"naked-single":     1.0,
+	"hidden-single":    1.2,
+	"pointing":         1.7,
+	"claiming":         1.8,
+	"naked-pair":       2.0,
+	"x-wing":           3.0,
+	"skyscraper":       3.2,
+	"two-string-kite":  3.3,
+	"swordfish":        3.6,
+	"xyz-wing":         3.8,
+	"remote-pairs":     4.2,
+	"unique-rectangle": 4.4,
+	"jellyfish":        4.8,
+	"3d-medusa":        5.5,
+	"nishio":           7.5,
+}
+
+// maxScore is the score of puzzles the techniques cannot solve.
+const maxScore = 10.0
+
+// Rating tells how hard a puzzle is to solve by hand.
+type Rating struct {
+	Score   float64 // from 1 (singles only) to 10 (beyond the techniques)
+	Level   string  // Easy, Medium, Hard or Diabolical, "" when not rated
+	Hardest string  // hardest technique needed, "" for a full grid
+	Solved  bool    // false when the techniques get stuck
+	Err     error   // why the puzzle was not solved, see Solver.Solve
+	Report  SolveReport
+}
+
+// Rate solves a copy of g with the default strategies, without
+// backtracking, and rates it, see RateReport. Puzzles the techniques
+// cannot solve need backtracking, and score 10; invalid puzzles and
+// puzzles without a single solution are not rated.
+func Rate(g Grid) Rating {
+	var trial = g.Clone()
+	trial.Verbose = false
+	trial.OnEvent = nil
+	if (!trial.listed) {
+		trial.ListOptions()
+	}
+	report, err := NewSolver().Solve(trial)
+	return RateReport(report, err)
+}
+
+// RateReport rates a puzzle from the report and the error of solving
+// it, so that a puzzle being solved anyway is not solved twice. The
+// score is the weight of the hardest technique needed, plus a tenth for
+// each other step beyond singles, up to 0.9: puzzles needing hard
+// techniques many times are harder. Backtracking scores 10, and puzzles
+// filled by a search explaining nothing, such as DLX, are not rated.
+func RateReport(report SolveReport, err error) Rating {
+	var rating = Rating{Solved: err == nil, Err: err, Report: report}
+	var notUnique *NotUniqueError
+	if (errors.As(err, &notUnique)) {
+		return rating
+	}
+	if (errors.Is(err, ErrNotSolved)) {
+		rating.Hardest = backtracking
+		rating.Score = maxScore
+		rating.Level = Diabolical
+		return rating
+	}
+	if (err != nil) {
+		return rating
+	}
+
+	var hardest float64 = techniqueWeights["naked-single"]
+	var beyondSingles int = 0
+	for _, step := range report.Steps {
+		_, weighed := techniqueWeights[step.Technique]
+		if (!weighed && step.Technique != backtracking) {
+			return rating
+		}
+		var weight = techniqueWeight(step.Technique)
+		if (rating.Hardest == "" || weight > hardest) {
+			hardest = math.Max(hardest, weight)
+			rating.Hardest = step.Technique
+		}
+		if (weight > techniqueWeights["hidden-single"]) {
+			beyondSingles++
+		}
+	}
+	var extra = math.Min(0.1*float64(max(beyondSingles-1, 0)), 0.9)
+	rating.Score = math.Min(math.Round((hardest+extra)*10)/10, maxScore)
+	rating.Level = level(rating.Score)
+	return rating
+}
+
+// techniqueWeight returns the weight of a technique, see
+// techniqueWeights.
+func techniqueWeight(technique string) float64 {
+	weight, ok := techniqueWeights[technique]
+	if (!ok) {
+		return maxScore
+	}
+	return weight
+}
+
+// level returns the difficulty level of a score.
+func level(score float64) string {
+	switch {
+	case score < 1.7:
+		return Easy
+	case score < 3.0:
+		return Medium
+	case score < 5.5:
+		return Hard
+	}
+	return Diabolical
+}
@@ -0,0 +1,47 @@
+package sudoku
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRate(t *testing.T) {
+	var tests = []struct {
+		name    string
+		puzzle  string
+		level   string
+		hardest string
+		solved  bool
+	}{
+		{"singles", concurrencyPuzzles[0].puzzle, Easy, "hidden-single", true},
+		{"nishio", nishioPuzzle, Diabolical, "nishio", true},
+		{"no solution", "11" + concurrencyPuzzles[0].puzzle[2:], "", "", false},
+	}
+
+	for _, test := range tests {
+		g, err := Parse(test.puzzle)
+		if (err != nil) {
+			t.Fatal(err)
+		}
+		var rating = Rate(*g)
+		if (rating.Level != test.level || rating.Hardest != test.hardest || rating.Solved != test.solved) {
+			t.Errorf("%s: got %s %.1f after %q, solved %v, want %s after %q, solved %v", test.name, rating.Level, rating.Score, rating.Hardest, rating.Solved, test.level, test.hardest, test.solved)
+		}
+		if (rating.Level != "" && level(rating.Score) != rating.Level) {
+			t.Errorf("%s: scored %.1f, which is not %s", test.name, rating.Score, rating.Level)
+		}
+	}
+}
+
+func TestRateNotUnique(t *testing.T) {
+	g, err := Parse("1" + strings.Repeat("0", 80))
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	var rating = Rate(*g)
+	var notUnique *NotUniqueError
+	if (rating.Level != "" || rating.Score != 0 || rating.Solved || !errors.As(rating.Err, &notUnique)) {
+		t.Errorf("got %s %.1f, solved %v, error %v, want an unrated puzzle", rating.Level, rating.Score, rating.Solved, rating.Err)
+	}
+}
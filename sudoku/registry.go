@@ -0,0 +1,79 @@
+package sudoku
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Strategy)
+	registered []string // names, in registration order
+)
+
+func init() {
+	Register(NakedSingle{})
+	Register(HiddenSingle{})
+	Register(NakedPair{})
+	Register(Pointing{})
+	Register(Claiming{})
+	Register(XWing{})
+	Register(Swordfish{})
+	Register(Jellyfish{})
+	Register(XYZWing{})
+	Register(Skyscraper{})
+	Register(TwoStringKite{})
+	Register(Medusa{})
+	Register(RemotePairs{})
+	Register(UniqueRectangle{})
+	Register(Nishio{})
+	Register(DLX{})
+}
+
+// Register makes a strategy available by its name, for example to be
+// selected on the command line. It is meant to be called from init
+// functions, and panics if the name is already taken.
+func Register(strategy Strategy) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	var name = strategy.Name()
+	_, taken := registry[name]
+	if (taken) {
+		panic("sudoku: strategy " + name + " registered twice")
+	}
+	registry[name] = strategy
+	registered = append(registered, name)
+}
+
+// Lookup returns the strategy registered with the given name.
+func Lookup(name string) (Strategy, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	strategy, ok := registry[name]
+	return strategy, ok
+}
+
+// StrategyNames returns the names of all registered strategies, in
+// registration order.
+func StrategyNames() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	return append([]string(nil), registered...)
+}
+
+// StrategiesByName returns the registered strategies with the given
+// names, in the same order.
+func StrategiesByName(names []string) ([]Strategy, error) {
+	var strategies []Strategy
+	for _, name := range names {
+		strategy, ok := Lookup(name)
+		if (!ok) {
+			return nil, fmt.Errorf("unknown strategy %q", name)
+		}
+		strategies = append(strategies, strategy)
+	}
+	return strategies, nil
+}
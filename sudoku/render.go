@@ -0,0 +1,446 @@
+package sudoku
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Format selects what Render writes.
+type Format int
+
+const (
+	// FormatGrid draws the grid, see SetStyle and SetLabels.
+	FormatGrid Format = iota
+
+	// FormatHints draws the grid, marking with ◆ the empty cells having
+	// a single option.
+	FormatHints
+
+	// FormatOptions draws the options of every empty cell.
+	FormatOptions
+
+	// FormatLine writes the 81 values on one line, 0 for empty cells.
+	FormatLine
+
+	// FormatSolution draws the grid, coloring the givens and the values
+	// placed by the solver differently, see also RenderSolution.
+	FormatSolution
+
+	// FormatLaTeX writes a sudoku environment of the LaTeX sudoku
+	// package, to include in documents with \usepackage{sudoku}.
+	FormatLaTeX
+
+	// FormatPencilMarks draws the options of every empty cell as a 3x3
+	// mini-grid of pencil marks, 1 at the top left, fitting in 37
+	// columns unlike FormatOptions.
+	FormatPencilMarks
+)
+
+// Render writes the grid to w in the given format.
+func (g *Grid) Render(w io.Writer, format Format) error {
+	settingsMu.RLock()
+	var r = settings
+	settingsMu.RUnlock()
+
+	var buf bytes.Buffer
+	switch format {
+	case FormatGrid, FormatHints, FormatSolution:
+		g.renderGrid(&buf, r, format, nil)
+	case FormatOptions:
+		g.renderOptions(&buf, r)
+	case FormatPencilMarks:
+		g.renderPencilMarks(&buf, r)
+	case FormatLine:
+		fmt.Fprintln(&buf, g.String())
+	case FormatLaTeX:
+		g.renderLaTeX(&buf)
+	default:
+		return fmt.Errorf("unknown format %d", format)
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Style describes how Render draws the grid.
+type Style struct {
+	pad     int  // spaces on each side of a cell
+	cellSep bool // draw a | between all cells, not only between squares
+	rowSep  bool // draw a line between all rows, not only between squares
+	boxLine byte // character of the lines around squares
+	unicode bool // draw with box-drawing characters, heavy around squares
+}
+
+// Styles are the available presets of Style.
+var Styles = map[string]Style{
+	"compact":         {1, false, false, '-', false},
+	"classic":         {1, true, true, '-', false},
+	"spacious":        {2, true, true, '=', false},
+	"unicode":         {1, true, true, '-', true},
+	"unicode-compact": {1, false, false, '-', true},
+}
+
+// Kinds of horizontal lines of the grid.
+const (
+	lineTop = iota
+	lineBox  // between squares
+	lineCell // between rows of a square
+	lineBottom
+)
+
+// unicodeJunctions gives, for each kind of line, the box-drawing
+// characters where it meets the left border, a heavy line, a light
+// line and the right border.
+var unicodeJunctions = [4][4]string{
+	lineTop:    {"┏", "┳", "┯", "┓"},
+	lineBox:    {"┣", "╋", "┿", "┫"},
+	lineCell:   {"┠", "╂", "┼", "┨"},
+	lineBottom: {"┗", "┻", "┷", "┛"},
+}
+
+// renderer holds the settings of Render. The package-wide settings are
+// guarded by settingsMu and copied for each call, so grids can be
+// rendered from several goroutines.
+type renderer struct {
+	style  Style
+	labels string
+	color  bool
+}
+
+var settingsMu sync.RWMutex
+var settings = renderer{Styles["classic"], "none", true}
+
+// ANSI codes of the colors used to highlight output.
+const (
+	colorRed    = "31"
+	colorBold   = "1"
+	colorBlue   = "34"
+	colorMarked = "7;34" // reversed blue
+)
+
+
+// SetColor enables or disables the ANSI colors the package uses to
+// highlight its output. Disable them when the output is not a terminal.
+func SetColor(enabled bool) {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	settings.color = enabled
+}
+
+// paint returns text in the given ANSI color, or as it is when colors
+// are disabled.
+func (r renderer) paint(color string, text string) string {
+	if (!r.color) {
+		return text
+	}
+	return "\033[" + color + "m" + text + "\033[0m"
+}
+
+// paint returns text in the given ANSI color, following SetColor.
+func paint(color string, text string) string {
+	settingsMu.RLock()
+	var r = settings
+	settingsMu.RUnlock()
+	return r.paint(color, text)
+}
+
+// SetStyle selects how Render draws the grid.
+func SetStyle(style Style) {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	settings.style = style
+}
+
+// SetLabels selects the axis labels Render draws around the grid: one of
+// "none", "rc" (r1-r9, c1-c9) or "alpha" (rows A-I, columns 1-9).
+func SetLabels(labels string) error {
+	if (labels != "none" && labels != "rc" && labels != "alpha") {
+		return fmt.Errorf("unknown labels %q, use none, rc or alpha", labels)
+	}
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	settings.labels = labels
+	return nil
+}
+
+// axisLabels returns the row and column labels, or nil when the grid
+// is drawn without labels.
+func (r renderer) axisLabels() ([]string, []string) {
+	var rowLabels, colLabels []string
+	for i := 1; i <= 9; i++ {
+		switch r.labels {
+		case "rc":
+			rowLabels = append(rowLabels, fmt.Sprintf("r%d", i))
+			colLabels = append(colLabels, fmt.Sprintf("c%d", i))
+		case "alpha":
+			rowLabels = append(rowLabels, string(rune('A'+i-1)))
+			colLabels = append(colLabels, fmt.Sprint(i))
+		}
+	}
+	return rowLabels, colLabels
+}
+
+// hasCellSep returns true if a | is drawn on the left of the given col.
+func (r renderer) hasCellSep(col int) bool {
+	return r.style.cellSep || col%3 == 0
+}
+
+// renderGridLine writes a horizontal line of the grid of the given
+// kind: lineTop, lineBox, lineCell or lineBottom.
+func (r renderer) renderGridLine(buf *bytes.Buffer, margin string, kind int) {
+	var ch = string(r.style.boxLine)
+	if (kind == lineCell) {
+		ch = "-"
+	}
+	if (r.style.unicode) {
+		ch = "━"
+		if (kind == lineCell) {
+			ch = "─"
+		}
+	}
+
+	var line = margin
+	for col := 0; col < 9; col++ {
+		var width int = 1 + r.style.pad
+		if (r.hasCellSep(col)) {
+			line += r.junction(kind, col)
+			width += r.style.pad
+		}
+		line += strings.Repeat(ch, width)
+	}
+	fmt.Fprintln(buf, line+r.junction(kind, 9))
+}
+
+// junction returns the character where a horizontal line of the given
+// kind crosses the vertical line on the left of col, 9 for the right
+// border.
+func (r renderer) junction(kind int, col int) string {
+	if (!r.style.unicode) {
+		return "+"
+	}
+	switch {
+	case col == 0:
+		return unicodeJunctions[kind][0]
+	case col == 9:
+		return unicodeJunctions[kind][3]
+	case col%3 == 0:
+		return unicodeJunctions[kind][1]
+	}
+	return unicodeJunctions[kind][2]
+}
+
+// verticalLine returns the character of the vertical line on the left
+// of col, 9 for the right border.
+func (r renderer) verticalLine(col int) string {
+	if (!r.style.unicode) {
+		return "|"
+	}
+	if (col%3 == 0) {
+		return "┃"
+	}
+	return "│"
+}
+
+// RenderSolution draws the grid as FormatSolution, marking the given
+// cells, such as the last ones the solver placed. Marked cells are
+// highlighted, or followed by * when colors are disabled. Marked empty
+// cells, such as cells which lost an option, show a highlighted -.
+func (g *Grid) RenderSolution(w io.Writer, marked []Coord) error {
+	settingsMu.RLock()
+	var r = settings
+	settingsMu.RUnlock()
+
+	var isMarked = make(map[Coord]bool)
+	for _, cell := range marked {
+		isMarked[cell] = true
+	}
+	var buf bytes.Buffer
+	g.renderGrid(&buf, r, FormatSolution, isMarked)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// renderGrid will write a nice ASCII version of the
+// 2-dimensional array representing the sudoku grid, in format
+// FormatGrid, FormatHints or FormatSolution.
+func (g *Grid) renderGrid(buf *bytes.Buffer, r renderer, format Format, marked map[Coord]bool) {
+	var rowLabels, colLabels = r.axisLabels()
+	var margin string = ""
+	if (rowLabels != nil) {
+		margin = strings.Repeat(" ", len(rowLabels[0])+1)
+	}
+
+	// column labels end right above the cell they name
+	if (colLabels != nil) {
+		var header = []byte(strings.Repeat(" ", len(margin)+9*(3+2*r.style.pad)))
+		var pos int = len(margin)
+		var spacing int = 1 + r.style.pad // between two cells of a square
+		if (r.style.cellSep) {
+			spacing += 1 + r.style.pad
+		}
+		for col := 0; col < 9; col++ {
+			if (r.hasCellSep(col)) {
+				pos += 1 + r.style.pad
+			}
+			var label = colLabels[col]
+			if (len(label) >= spacing) {
+				label = label[len(label)-1:]
+			}
+			copy(header[pos-len(label)+1:], label)
+			pos += 1 + r.style.pad
+		}
+		fmt.Fprintln(buf, strings.TrimRight(string(header), " "))
+	}
+
+	var padding = strings.Repeat(" ", r.style.pad)
+	r.renderGridLine(buf, margin, lineTop)
+	for row := 0; row < 9; row++ {
+		if (rowLabels != nil) {
+			fmt.Fprint(buf, rowLabels[row] + " ")
+		}
+		for col := 0; col < 9; col++ {
+			if (r.hasCellSep(col)) {
+				fmt.Fprint(buf, r.verticalLine(col) + padding)
+			}
+			var text string = " "
+			var isMarked = marked[Coord{row, col}]
+			if (g.cells[row][col] != 0) {
+				text = fmt.Sprint(g.cells[row][col])
+				if (format == FormatSolution) {
+					switch {
+					case isMarked:
+						text = r.paint(colorMarked, text)
+					case g.givens[row][col]:
+						text = r.paint(colorBold, text)
+					default:
+						text = r.paint(colorBlue, text)
+					}
+				}
+			} else if (format == FormatHints && g.options[row][col].count() == 1) {
+				text = r.paint(colorRed, "◆")
+			} else if (format == FormatSolution && isMarked && r.color) {
+				text = r.paint(colorMarked, "-")
+			}
+			if (isMarked && !r.color && r.style.pad > 0) {
+				text += "*" + padding[1:]
+			} else {
+				text += padding
+			}
+			fmt.Fprint(buf, text)
+		}
+		fmt.Fprintln(buf, r.verticalLine(9))
+		if (row == 8) {
+			r.renderGridLine(buf, margin, lineBottom)
+		} else if (row%3 == 2) {
+			r.renderGridLine(buf, margin, lineBox)
+		} else if (r.style.rowSep) {
+			r.renderGridLine(buf, margin, lineCell)
+		}
+	}
+}
+
+// renderOptions writes the options of every empty cell.
+func (g *Grid) renderOptions(buf *bytes.Buffer, r renderer) {
+	fmt.Fprintln(buf, "+---------------+---------------+---------------+---------------+---------------+---------------+---------------+---------------+---------------+")
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			fmt.Fprint(buf, "| ")
+			if (g.cells[row][col] != 0) {
+				fmt.Fprint(buf, r.paint(colorRed, fmt.Sprintf("%-13d", g.cells[row][col])))
+			} else {
+				var strOptions = strings.Trim(strings.Join(strings.Fields(fmt.Sprint(g.options[row][col].values())), " "), "[]")
+				fmt.Fprintf(buf, "%-13s", strOptions)
+			}
+			fmt.Fprint(buf, " ")
+		}
+		fmt.Fprintln(buf, "|")
+		fmt.Fprintln(buf, "+---------------+---------------+---------------+---------------+---------------+---------------+---------------+---------------+---------------+")
+	}
+}
+
+// renderPencilMarks writes the options of every empty cell as a 3x3
+// mini-grid, with dots for the values which are not options, and the
+// value of solved cells in the middle. Squares are bordered with #:
+//   #===+===+===#===
+//   #1.3|   |...#
+//   #...| 5 |.5.#
+//   #..9|   |...#
+//   #---+---+---#---
+func (g *Grid) renderPencilMarks(buf *bytes.Buffer, r renderer) {
+	var border = func(ch string) {
+		var line string = ""
+		for col := 0; col < 9; col++ {
+			if (col%3 == 0) {
+				line += "#"
+			} else {
+				line += "+"
+			}
+			line += strings.Repeat(ch, 3)
+		}
+		fmt.Fprintln(buf, line+"#")
+	}
+
+	for row := 0; row < 9; row++ {
+		if (row%3 == 0) {
+			border("=")
+		} else {
+			border("-")
+		}
+		for band := 0; band < 3; band++ {
+			var line string = ""
+			for col := 0; col < 9; col++ {
+				if (col%3 == 0) {
+					line += "#"
+				} else {
+					line += "|"
+				}
+				var value = g.cells[row][col]
+				if (value != 0) {
+					if (band != 1) {
+						line += "   "
+						continue
+					}
+					var text = r.paint(colorBlue, fmt.Sprint(value))
+					if (g.givens[row][col]) {
+						text = r.paint(colorBold, fmt.Sprint(value))
+					}
+					line += " " + text + " "
+					continue
+				}
+				for value := 3*band + 1; value <= 3*band+3; value++ {
+					if (g.options[row][col].has(value)) {
+						line += fmt.Sprint(value)
+					} else {
+						line += "."
+					}
+				}
+			}
+			fmt.Fprintln(buf, line+"#")
+		}
+	}
+	border("=")
+}
+
+// renderLaTeX writes the grid as rows of the sudoku environment:
+//   \begin{sudoku}
+//   | | |6| | | |3| | |.
+//   ...
+//   \end{sudoku}
+func (g *Grid) renderLaTeX(buf *bytes.Buffer) {
+	fmt.Fprintln(buf, "\\begin{sudoku}")
+	for row := 0; row < 9; row++ {
+		var line = "|"
+		for col := 0; col < 9; col++ {
+			if (g.cells[row][col] != 0) {
+				line += fmt.Sprint(g.cells[row][col])
+			} else {
+				line += " "
+			}
+			line += "|"
+		}
+		fmt.Fprintln(buf, line+".")
+	}
+	fmt.Fprintln(buf, "\\end{sudoku}")
+}
@@ -0,0 +1,117 @@
+package sudoku
+
+import (
+	"math/bits"
+)
+
+// repairSample is the number of other solutions Repair looks at to
+// choose the clues to add.
+const repairSample = 64
+
+// repairMaxExact is the size of the largest set of clues Repair looks
+// for exhaustively among the other solutions sampled.
+const repairMaxExact = 3
+
+// Repair returns the puzzle g with clues added, taken from one of its
+// solutions, so that it has a single solution, along with the cells
+// added. It samples the other solutions and adds the fewest clues
+// ruling them all out, until none is left, then drops the added clues
+// which turned out not to be needed. It returns ErrNoSolution when g
+// has no solution, and g unchanged when it has a single one.
+func (g *Grid) Repair() (*Grid, []Coord, error) {
+	var solutions = g.AllSolutions(1)
+	if (len(solutions) == 0) {
+		return nil, nil, ErrNoSolution
+	}
+	var solution = solutions[0].cells
+
+	var cells = g.cells
+	var added []Coord
+	for {
+		var others [][9][9]int
+		dlxSearch(givenSets(cells), func(found [9][9]int) bool {
+			if (found != solution) {
+				others = append(others, found)
+			}
+			return len(others) < repairSample
+		})
+		if (len(others) == 0) {
+			break
+		}
+		for _, cell := range ruleOut(solution, others) {
+			cells[cell.Row][cell.Col] = solution[cell.Row][cell.Col]
+			added = append(added, cell)
+		}
+	}
+
+	// a clue added early may be made useless by the later ones
+	for i := 0; i < len(added); {
+		var cell = added[i]
+		cells[cell.Row][cell.Col] = 0
+		if (countSolutions(cells, 2) == 1) {
+			added = append(added[:i], added[i+1:]...)
+			continue
+		}
+		cells[cell.Row][cell.Col] = solution[cell.Row][cell.Col]
+		i++
+	}
+
+	var repaired = NewGrid(cells)
+	repaired.Verbose = g.Verbose
+	return repaired, added, nil
+}
+
+// ruleOut returns the fewest cells, up to repairMaxExact, whose values
+// in solution differ from each of the others, at most 64. When more are
+// needed, it returns the cell ruling out most of them.
+func ruleOut(solution [9][9]int, others [][9][9]int) []Coord {
+	// which of the others each cell rules out
+	var cells []Coord
+	var masks []uint64
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			var mask uint64 = 0
+			for i, other := range others {
+				if (other[row][col] != solution[row][col]) {
+					mask |= 1 << i
+				}
+			}
+			if (mask != 0) {
+				cells = append(cells, Coord{row, col})
+				masks = append(masks, mask)
+			}
+		}
+	}
+	var all uint64 = 1<<len(others) - 1
+
+	var found []Coord
+	var search func(start int, chosen []Coord, mask uint64, size int) bool
+	search = func(start int, chosen []Coord, mask uint64, size int) bool {
+		if (len(chosen) == size) {
+			if (mask == all) {
+				found = append([]Coord{}, chosen...)
+				return true
+			}
+			return false
+		}
+		for i := start; i < len(cells); i++ {
+			if (search(i+1, append(chosen, cells[i]), mask|masks[i], size)) {
+				return true
+			}
+		}
+		return false
+	}
+	for size := 1; size <= repairMaxExact; size++ {
+		if (search(0, nil, 0, size)) {
+			return found
+		}
+	}
+
+	var best int = 0
+	for i := range cells {
+		if (bits.OnesCount64(masks[i]) > bits.OnesCount64(masks[best])) {
+			best = i
+		}
+	}
+	return []Coord{cells[best]}
+}
@@ -0,0 +1,89 @@
+package sudoku
+
+import (
+	"time"
+)
+
+// StepReport tells what a strategy changed during a pass of the solver.
+type StepReport struct {
+	Pass       int    // from 1
+	Technique  string // name of the strategy
+	Placed     int
+	Eliminated int
+}
+
+// SolveReport describes how the solver went through a grid.
+type SolveReport struct {
+	Solved     bool
+	Passes     int
+	Techniques []string     // strategies that made progress, in order of first use
+	Steps      []StepReport // every strategy application that made progress
+	EmptyCells int          // left when the solver stopped
+	Elapsed    time.Duration
+}
+
+// add records the progress of a strategy during the current pass.
+func (r *SolveReport) add(technique string, progress Progress) {
+	if (!progress.Made()) {
+		return
+	}
+
+	var known bool = false
+	for _, name := range r.Techniques {
+		known = known || name == technique
+	}
+	if (!known) {
+		r.Techniques = append(r.Techniques, technique)
+	}
+
+	r.Steps = append(r.Steps, StepReport{
+		Pass:       r.Passes,
+		Technique:  technique,
+		Placed:     progress.Placed,
+		Eliminated: progress.Eliminated,
+	})
+}
+
+// Eliminated returns the number of options removed over all steps.
+func (r *SolveReport) Eliminated() int {
+	var total int = 0
+	for _, step := range r.Steps {
+		total += step.Eliminated
+	}
+	return total
+}
+
+// Placed returns the number of cells filled over all steps.
+func (r *SolveReport) Placed() int {
+	var total int = 0
+	for _, step := range r.Steps {
+		total += step.Placed
+	}
+	return total
+}
+
+// TechniqueStats tallies what a technique did over a solve.
+type TechniqueStats struct {
+	Technique  string
+	Uses       int // steps in which it made progress
+	Placed     int
+	Eliminated int
+}
+
+// Stats returns the tally of each technique of the report, in order of
+// first use.
+func (r *SolveReport) Stats() []TechniqueStats {
+	var stats []TechniqueStats
+	for _, technique := range r.Techniques {
+		var tally = TechniqueStats{Technique: technique}
+		for _, step := range r.Steps {
+			if (step.Technique == technique) {
+				tally.Uses++
+				tally.Placed += step.Placed
+				tally.Eliminated += step.Eliminated
+			}
+		}
+		stats = append(stats, tally)
+	}
+	return stats
+}
@@ -0,0 +1,92 @@
+package sudoku
+
+// Skyscraper removes a value from the cells seeing the tops of two
+// towers: two rows where the value has exactly two places, one of
+// them in the same column for both rows (the base). One of the rows
+// holds the value away from the base, at the top of its tower. The
+// same goes for rows and columns swapped.
+type Skyscraper struct{}
+
+func (Skyscraper) Name() string {
+	return "skyscraper"
+}
+
+func (s Skyscraper) Apply(g *Grid) (Progress, error) {
+	var progress Progress
+	for value := 1; value <= 9; value++ {
+		for _, lines := range [][]House{houses[9:18], houses[18:]} {
+			var links = g.strongLinks(value, lines)
+			for i, a := range links {
+				for _, b := range links[i+1:] {
+					for _, ends := range [][4]Coord{{a[0], a[1], b[0], b[1]}, {a[0], a[1], b[1], b[0]}, {a[1], a[0], b[0], b[1]}, {a[1], a[0], b[1], b[0]}} {
+						// ends[0] and ends[2] form the base, in the same
+						// column for rows, or the same row for columns
+						var aligned = ends[0].Col == ends[2].Col
+						var topsAligned = ends[1].Col == ends[3].Col
+						if (lines[0].Kind == ColHouse) {
+							aligned = ends[0].Row == ends[2].Row
+							topsAligned = ends[1].Row == ends[3].Row
+						}
+						if (aligned && !topsAligned) {
+							g.eliminateSeenByBoth(s.Name(), value, ends[1], ends[3], &progress)
+						}
+					}
+				}
+			}
+		}
+	}
+	return progress, nil
+}
+
+// TwoStringKite removes a value from the cells seeing the ends of a
+// kite: a row and a column where the value has exactly two places, one
+// of each in the same square. One of the other two places holds the
+// value, which is removed from the cells seeing both.
+type TwoStringKite struct{}
+
+func (TwoStringKite) Name() string {
+	return "two-string-kite"
+}
+
+func (k TwoStringKite) Apply(g *Grid) (Progress, error) {
+	var progress Progress
+	for value := 1; value <= 9; value++ {
+		for _, row := range g.strongLinks(value, houses[9:18]) {
+			for _, col := range g.strongLinks(value, houses[18:]) {
+				for _, ends := range [][4]Coord{{row[0], row[1], col[0], col[1]}, {row[0], row[1], col[1], col[0]}, {row[1], row[0], col[0], col[1]}, {row[1], row[0], col[1], col[0]}} {
+					// ends[0] and ends[2] share a square, the string
+					// ends are ends[1] and ends[3]
+					if (ends[0] == ends[2] || ends[0] == ends[3] || ends[1] == ends[2] || ends[0].Square() != ends[2].Square()) {
+						continue
+					}
+					g.eliminateSeenByBoth(k.Name(), value, ends[1], ends[3], &progress)
+				}
+			}
+		}
+	}
+	return progress, nil
+}
+
+// strongLinks returns the pairs of cells of the lines which are the
+// only two places of value in their line: one of both holds it.
+func (g *Grid) strongLinks(value int, lines []House) [][2]Coord {
+	var links [][2]Coord
+	for _, line := range lines {
+		var places = g.placesOf(line, value)
+		if (len(places) == 2) {
+			links = append(links, [2]Coord{places[0], places[1]})
+		}
+	}
+	return links
+}
+
+// eliminateSeenByBoth removes value from the cells seeing both a and b,
+// one of which holds it.
+func (g *Grid) eliminateSeenByBoth(technique string, value int, a Coord, b Coord, progress *Progress) {
+	for _, cell := range peers[a.Row][a.Col] {
+		if (cell == b || !cell.Sees(b)) {
+			continue
+		}
+		g.eliminate(progress, technique, cell, value, "", "")
+	}
+}
@@ -0,0 +1,104 @@
+package sudoku
+
+import (
+	"testing"
+)
+
+func TestSkyscraper(t *testing.T) {
+	var tests = []struct {
+		name       string
+		value      int
+		picture    string
+		eliminated string
+	}{
+		{
+			"rows", 4, `
+				x...x....
+				xxxxxxxxx
+				x....x...
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx`,
+			"r2c4-4 r2c5-4 r2c6-4",
+		},
+		{
+			"columns", 3, `
+				xxx.xxxxx
+				x.x.xxxxx
+				x.xxxxxxx
+				x.x.xxxxx
+				x.x.xxxxx
+				x.x.xxxxx
+				x.x.xxxxx
+				x.x.xxxxx
+				xxxxxxxxx`,
+			"r1c5-3 r1c6-3 r3c1-3 r3c3-3",
+		},
+		{
+			"tops in the same column", 4, `
+				x...x....
+				xxxxxxxxx
+				x...x....
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx
+				xxxxxxxxx`,
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		var eliminated = eliminations(t, Skyscraper{}, valueGrid(t, test.value, test.picture))
+		if (eliminated != test.eliminated) {
+			t.Errorf("%s: eliminated %s, want %s", test.name, eliminated, test.eliminated)
+		}
+	}
+}
+
+func TestTwoStringKite(t *testing.T) {
+	var tests = []struct {
+		name       string
+		value      int
+		picture    string
+		eliminated string
+	}{
+		{
+			"ends of the strings in the same square", 5, `
+				x.xxxxxxx
+				x....x...
+				xxxxxxxxx
+				x.xxxxxxx
+				x.xxxxxxx
+				x.xxxxxxx
+				x.xxxxxxx
+				xxxxxxxxx
+				x.xxxxxxx`,
+			"r8c6-5",
+		},
+		{
+			"strings in different squares", 5, `
+				xxxxxxxx.
+				x....x...
+				xxxxxxxx.
+				xxxxxxxx.
+				xxxxxxxxx
+				xxxxxxxx.
+				xxxxxxxx.
+				xxxxxxxxx
+				xxxxxxxx.`,
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		var eliminated = eliminations(t, TwoStringKite{}, valueGrid(t, test.value, test.picture))
+		if (eliminated != test.eliminated) {
+			t.Errorf("%s: eliminated %s, want %s", test.name, eliminated, test.eliminated)
+		}
+	}
+}
@@ -0,0 +1,386 @@
+// Package sudoku solves Sudoku grids. Load a grid with Parse, compute
+// the options of its empty cells with ListOptions, then fill it with
+// Step or Solve.
+//
+// The package holds no solving state: a Grid or a Solver must not be
+// used by several goroutines at once, but independent grids and solvers
+// can run concurrently.
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	// ErrInvalidLength is returned when a grid does not have 81 values.
+	ErrInvalidLength = errors.New("not a valid grid, submit 81 values")
+
+	// ErrInvalidChar is returned when a grid value is not a number
+	// from 1 to 9 or an empty cell marker.
+	ErrInvalidChar = errors.New("not a valid grid, values must be numbers from 1 to 9, or 0, . or _ for empty cells")
+
+	// ErrNotSolved is returned when the solver cannot fill the grid.
+	ErrNotSolved = errors.New("could not solve")
+
+	// ErrNoSolution is returned when no value of the options of the
+	// empty cells completes the grid: the clues contradict each other.
+	ErrNoSolution = errors.New("no solution, the clues contradict each other")
+)
+
+// Grid is a Sudoku grid being solved. Copying a Grid copies its whole
+// state.
+type Grid struct {
+	// Contains the full grid, with secured numbers
+	cells [9][9]int
+
+	// Contains a grid of options for each empty cell.
+	// If a cell is not empty, it has no option.
+	options [9][9]digitSet
+
+	// listed is true once the options have been listed or set, see
+	// ListOptions.
+	listed bool
+
+	// Pencil marks the puzzle was given with, see ParseSukaku. Empty for
+	// cells without marks. Options ruled out by the marks are never
+	// listed again.
+	marks [9][9]digitSet
+
+	// Cells filled in the puzzle, as opposed to by the solver.
+	givens [9][9]bool
+
+	// Verbose makes the solver explain its deductions on stdout as it
+	// goes. It is off by default.
+	Verbose bool
+
+	// OnEvent, when set, receives the deductions instead of them being
+	// explained in Verbose mode, see JSONLines. It is not encoded.
+	OnEvent func(Event) `json:"-"`
+}
+
+// NewGrid returns a grid holding the given cells, 0 for empty.
+// The filled cells are the givens of the puzzle.
+func NewGrid(cells [9][9]int) *Grid {
+	var g = &Grid{cells: cells}
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			g.givens[row][col] = cells[row][col] != 0
+		}
+	}
+	return g
+}
+
+// IsGiven returns true if the cell was filled in the puzzle, rather
+// than by the solver.
+func (g *Grid) IsGiven(row int, col int) bool {
+	return g.givens[row][col]
+}
+
+// Clone returns a copy of the grid, which can be solved without
+// changing the original.
+func (g *Grid) Clone() *Grid {
+	var clone = *g
+	return &clone
+}
+
+// Cells returns the values of all cells, 0 for empty.
+func (g *Grid) Cells() [9][9]int {
+	return g.cells
+}
+
+// Options returns the options of all cells.
+func (g *Grid) Options() [9][9][]int {
+	var options [9][9][]int
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			options[row][col] = g.options[row][col].values()
+		}
+	}
+	return options
+}
+
+// SetOptions replaces the options of all cells, for example to resume
+// from a saved state. It returns an error leaving the grid unchanged
+// when an option is not a number from 1 to 9.
+func (g *Grid) SetOptions(options [9][9][]int) error {
+	err := checkCandidates(options)
+	if (err != nil) {
+		return err
+	}
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			g.options[row][col] = digitSetOf(options[row][col])
+		}
+	}
+	g.listed = true
+	return nil
+}
+
+// SetCell sets the value of a cell, 0 to clear it, and updates the
+// options. Changing a filled cell lists them again, as its old value
+// may come back to its peers.
+func (g *Grid) SetCell(row int, col int, value int) {
+	if (value != 0 && g.listed && g.cells[row][col] == 0) {
+		g.place(Coord{row, col}, value)
+		return
+	}
+	g.cells[row][col] = value
+	g.options[row][col] = 0
+	g.ListOptions()
+}
+
+// String converts the grid to its 81 digits string, line by line.
+// It is the reverse of Parse.
+func (g *Grid) String() string {
+	return CellsString(g.cells, '0')
+}
+
+// MarshalText encodes the grid as its 81 digits string, so grids can be
+// used as flag values or stored in text formats.
+func (g Grid) MarshalText() ([]byte, error) {
+	return []byte(g.String()), nil
+}
+
+// UnmarshalText decodes an 81 digits string, see Parse. The options of
+// the grid are left to be listed.
+func (g *Grid) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if (err != nil) {
+		return err
+	}
+	g.cells = parsed.cells
+	g.options = parsed.options
+	g.listed = parsed.listed
+	g.marks = parsed.marks
+	g.givens = parsed.givens
+	return nil
+}
+
+// ParseGrid reads a Sudoku grid from r. The input must contain
+// only digits from 1 to 9, 0, . or _ for empty cells, and whitespace
+// which is ignored. The values will fill the grid line by line. For
+// example, the input
+//   12....050800400030000050958...
+// will fill the grid
+//   +---+---+---+---+---+---+---+---+---+
+//   | 1 | 2 |   |   |   |   |   | 5 |   |
+//   +---+---+---+---+---+---+---+---+---+
+//   | 8 |   |   | 4 |   |   |   | 3 |   |
+//   +---+---+---+---+---+---+---+---+---+
+//   ...
+// An input of 729 values is read as pencil marks, see ParseSukaku.
+func ParseGrid(r io.Reader) (*Grid, error) {
+	data, err := io.ReadAll(r)
+	if (err != nil) {
+		return nil, err
+	}
+	var str = strings.Join(strings.Fields(string(data)), "")
+	if (len(str) == 729) {
+		return ParseSukaku(str)
+	}
+
+	// check string is 81 values
+	if (len(str) != 81) {
+		return nil, fmt.Errorf("%w, got %d", ErrInvalidLength, len(str))
+	}
+
+	// check all values are valid, and convert string to grid
+	cells, err := ParseCells(str)
+	if (err != nil) {
+		return nil, err
+	}
+	return NewGrid(cells), nil
+}
+
+// Parse converts a string to a Sudoku grid, see ParseGrid.
+func Parse(str string) (*Grid, error) {
+	return ParseGrid(strings.NewReader(str))
+}
+
+// getSquareFromRowCol returns the number of the square given
+// the column and row. Squares are distributed as following
+// 3x3 subgrids:
+// +---+---+---+---+---+---+---+---+---+
+// |   |   |   |   |   |   |   |   |   |
+// +---+---+---+---+---+---+---+---+---+
+// |   | 1 |   |   | 2 |   |   | 3 |   |
+// +---+---+---+---+---+---+---+---+---+
+// |   |   |   |   |   |   |   |   |   |
+// +---+---+---+---+---+---+---+---+---+
+// |   |   |   |   |   |   |   |   |   |
+// +---+---+---+---+---+---+---+---+---+
+// |   | 4 |   |   | 5 |   |   | 6 |   |
+// +---+---+---+---+---+---+---+---+---+
+// |   |   |   |   |   |   |   |   |   |
+// +---+---+---+---+---+---+---+---+---+
+// |   |   |   |   |   |   |   |   |   |
+// +---+---+---+---+---+---+---+---+---+
+// |   | 7 |   |   | 8 |   |   | 9 |   |
+// +---+---+---+---+---+---+---+---+---+
+// |   |   |   |   |   |   |   |   |   |
+// +---+---+---+---+---+---+---+---+---+
+func getSquareFromRowCol(row int, col int) int {
+	var rowOffset int = (row / 3) * 3
+	var colOffset int = (col / 3)
+
+	return (colOffset + 1) + rowOffset
+}
+
+// CountEmptyCells returns the number of zeros in the grid.
+func (g *Grid) CountEmptyCells() int {
+	var numEmpty int = 0
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (g.cells[row][col] == 0) {
+				numEmpty++
+			}
+		}
+	}
+
+	return numEmpty
+}
+
+// ListOptions lists the possible options of each empty cell in the grid,
+// from scratch. It is needed once before solving: placements then only
+// remove their value from the options of their peers, see place.
+func (g *Grid) ListOptions() {
+	// values placed in each row, column and square
+	var rows, cols, squares [9]digitSet
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (g.cells[row][col] != 0) {
+				var placed digitSet = 1 << g.cells[row][col]
+				rows[row] |= placed
+				cols[col] |= placed
+				squares[getSquareFromRowCol(row, col)-1] |= placed
+			}
+		}
+	}
+
+	g.listed = true
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (g.cells[row][col] != 0) {
+				g.options[row][col] = 0
+				continue
+			}
+
+			var options = allDigits &^ (rows[row] | cols[col] | squares[getSquareFromRowCol(row, col)-1])
+			if (g.marks[row][col] != 0) {
+				options &= g.marks[row][col]
+			}
+			g.options[row][col] = options
+			if (options.count() == 1) {
+				g.emit(Event{Placement, NakedSingle{}.Name(), "", Coord{row, col}, options.first(), ""})
+			}
+		}
+	}
+}
+
+// fillSecuredOptions will replace in grid what g.options found
+// as the only reliable option, and returns the filled cells.
+func (g *Grid) fillSecuredOptions() []Coord {
+	var filled []Coord
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (g.options[row][col].count() == 1) {
+				g.cells[row][col] = g.options[row][col].first()
+				g.options[row][col] = 0 // reset options for this cell.
+				filled = append(filled, Coord{row, col})
+			}
+		}
+	}
+	return filled
+}
+
+// place fills the cell with value and removes it from the options of
+// its 20 peers, keeping the other options as they are.
+func (g *Grid) place(cell Coord, value int) {
+	g.cells[cell.Row][cell.Col] = value
+	g.options[cell.Row][cell.Col] = 0
+	g.removeFromPeers([]Coord{cell})
+}
+
+// removeFromPeers removes the values of the filled cells from the
+// options of their peers. Unlike ListOptions, it keeps the options
+// other strategies have eliminated. Cells left with a single option
+// are reported as naked singles.
+func (g *Grid) removeFromPeers(filled []Coord) {
+	for _, cell := range filled {
+		var value int = g.cells[cell.Row][cell.Col]
+		for _, peer := range peers[cell.Row][cell.Col] {
+			if (g.Eliminate(peer.Row, peer.Col, value) && g.options[peer.Row][peer.Col].count() == 1) {
+				g.emit(Event{Placement, NakedSingle{}.Name(), "", peer, g.options[peer.Row][peer.Col].first(), ""})
+			}
+		}
+	}
+}
+
+// reduceOptionsFromUniqueOccurenceInHouse narrows the options of each
+// cell of the house holding the only place left for a value down to
+// that value, for all such values at once.
+func (g *Grid) reduceOptionsFromUniqueOccurenceInHouse(house House) {
+	// values seen in at least one cell, and in at least two
+	var once, twice digitSet
+	for _, cell := range house.Cells {
+		var options = g.options[cell.Row][cell.Col]
+		twice |= once & options
+		once |= options
+	}
+	var unique = once &^ twice
+
+	for _, value := range unique.values() {
+		for _, cell := range house.Cells {
+			if (g.HasCandidate(cell.Row, cell.Col, value)) {
+				g.emit(Event{Placement, HiddenSingle{}.Name(), house.String(), cell, value, ""})
+			}
+		}
+	}
+
+	// a cell holding the only place of two values keeps both, and is
+	// left to the contradiction checks
+	for _, cell := range house.Cells {
+		var hidden = g.options[cell.Row][cell.Col] & unique
+		if (hidden != 0) {
+			g.options[cell.Row][cell.Col] = hidden
+		}
+	}
+}
+
+// reduceOptionsFromUniqueOccurence will select options that can't be elsewhere
+// on the square, the row or the column. The given cell can have multiple options
+// but only one cell of the squar/row/column can ultimately host it; e.g. the other
+// cells does not have this possible option.
+func (g *Grid) reduceOptionsFromUniqueOccurence() {
+	// Browse all squares, then rows, then cols
+	for _, house := range houses {
+		g.reduceOptionsFromUniqueOccurenceInHouse(house)
+	}
+}
+
+// Step applies each default strategy once and returns true if it
+// filled at least one cell. ListOptions must have been called first.
+func (g *Grid) Step() (bool, error) {
+	return NewSolver().Step(g)
+}
+
+// Solve solves a copy of g with the default strategies and returns it,
+// leaving g untouched. On error, the copy is returned as far as the
+// solver got. The options of g must have been listed.
+func Solve(g Grid) (Grid, error) {
+	var solved = g.Clone()
+	err := solved.Solve()
+	return *solved, err
+}
+
+// Solve runs the default strategies until the grid is full or no more
+// cell can be filled, in which case it returns an error wrapping
+// ErrNotSolved, see Solver.Solve.
+// ListOptions must have been called first.
+func (g *Grid) Solve() error {
+	_, err := NewSolver().Solve(g)
+	return err
+}
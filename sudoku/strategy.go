@@ -0,0 +1,288 @@
+package sudoku
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"time"
+)
+
+// StepKind tells whether a step fills a cell or removes an option.
+type StepKind int
+
+const (
+	Placement StepKind = iota
+	Elimination
+)
+
+// Step is a single deduction: a value placed in a cell, or removed
+// from its options.
+type Step struct {
+	Kind      StepKind
+	Technique string
+	Row       int
+	Col       int
+	Value     int
+}
+
+// Progress tells what a strategy changed in the grid.
+type Progress struct {
+	Placed     int    // cells filled
+	Eliminated int    // options removed from cells
+	Steps      []Step // the deductions, in the order they were made
+}
+
+// add records a deduction made by technique.
+func (p *Progress) add(technique string, kind StepKind, row int, col int, value int) {
+	p.Steps = append(p.Steps, Step{kind, technique, row, col, value})
+	if (kind == Placement) {
+		p.Placed++
+	} else {
+		p.Eliminated++
+	}
+}
+
+// eliminate removes value from the options of cell, recording the
+// elimination in progress and reporting it as an event, with the house
+// and the reason explaining it when there are.
+func (g *Grid) eliminate(progress *Progress, technique string, cell Coord, value int, house string, reason string) {
+	if (g.Eliminate(cell.Row, cell.Col, value)) {
+		progress.add(technique, Elimination, cell.Row, cell.Col, value)
+		g.emit(Event{Elimination, technique, house, cell, value, reason})
+	}
+}
+
+// Made returns true if the grid changed.
+func (p Progress) Made() bool {
+	return p.Placed > 0 || p.Eliminated > 0
+}
+
+// Strategy is a solving technique. Apply looks for deductions in the
+// grid, applies them, and reports what changed. Strategies expect the
+// options of the grid to be listed, and keep them up to date.
+type Strategy interface {
+	Name() string
+	Apply(g *Grid) (Progress, error)
+}
+
+// NakedSingle fills the cells having a single option.
+type NakedSingle struct{}
+
+func (NakedSingle) Name() string {
+	return "naked-single"
+}
+
+func (n NakedSingle) Apply(g *Grid) (Progress, error) {
+	var progress Progress
+	var filled = g.fillSecuredOptions()
+	for _, cell := range filled {
+		progress.add(n.Name(), Placement, cell.Row, cell.Col, g.cells[cell.Row][cell.Col])
+	}
+	g.removeFromPeers(filled)
+	return progress, nil
+}
+
+// HiddenSingle fills the cells holding the only place left for a value
+// in their square, row or column.
+type HiddenSingle struct{}
+
+func (HiddenSingle) Name() string {
+	return "hidden-single"
+}
+
+func (h HiddenSingle) Apply(g *Grid) (Progress, error) {
+	var progress Progress
+	g.reduceOptionsFromUniqueOccurence()
+	var filled = g.fillSecuredOptions()
+	for _, cell := range filled {
+		progress.add(h.Name(), Placement, cell.Row, cell.Col, g.cells[cell.Row][cell.Col])
+	}
+	g.removeFromPeers(filled)
+	return progress, nil
+}
+
+// DefaultStrategies returns the strategies used by NewSolver, those of
+// the Tiers which are not opt-in, from the cheapest to the most
+// expensive. Strategies too expensive for most puzzles, such as
+// Jellyfish, are left out.
+func DefaultStrategies() []Strategy {
+	var names []string
+	for _, tier := range Tiers {
+		if (!tier.OptIn) {
+			names = append(names, tier.Strategies...)
+		}
+	}
+	strategies, err := StrategiesByName(names)
+	if (err != nil) {
+		panic("sudoku: " + err.Error())
+	}
+	SortStrategies(strategies)
+	return strategies
+}
+
+// Solver runs its strategies on a grid until it is solved or stuck. A
+// Solver is meant for one goroutine at a time, which its callbacks are
+// called from. Copies of it may share their Strategies, which keep no
+// state.
+type Solver struct {
+	Strategies []Strategy
+
+	// Backtrack makes the solver search the rest of the solution depth
+	// first when the strategies are stuck, instead of returning
+	// ErrNotSolved. The cells it fills are reported as backtracking.
+	// Puzzles having several solutions still return a *NotUniqueError.
+	Backtrack bool
+
+	onPlacement   []func(Step)
+	onElimination []func(Step)
+	onPass        []func(*Grid)
+}
+
+// OnPlacement registers a function called for each cell the solver
+// fills, right after the strategy making it returns.
+func (s *Solver) OnPlacement(fn func(Step)) {
+	s.onPlacement = append(s.onPlacement, fn)
+}
+
+// OnElimination registers a function called for each option the solver
+// removes, right after the strategy making it returns.
+func (s *Solver) OnElimination(fn func(Step)) {
+	s.onElimination = append(s.onElimination, fn)
+}
+
+// OnPass registers a function called with the grid after each pass of
+// Solve making progress, for example to show the options left.
+func (s *Solver) OnPass(fn func(*Grid)) {
+	s.onPass = append(s.onPass, fn)
+}
+
+// notify calls the observers of each step.
+func (s *Solver) notify(steps []Step) {
+	for _, step := range steps {
+		var observers = s.onPlacement
+		if (step.Kind == Elimination) {
+			observers = s.onElimination
+		}
+		for _, fn := range observers {
+			fn(step)
+		}
+	}
+}
+
+// NewSolver returns a solver using the default strategies.
+func NewSolver() *Solver {
+	return &Solver{Strategies: DefaultStrategies()}
+}
+
+// Step applies the strategies in order until one of them changes the
+// grid, and returns true if it did. Expensive strategies thus only run
+// when the simpler ones are stuck.
+func (s *Solver) Step(g *Grid) (bool, error) {
+	var report SolveReport
+	return s.step(context.Background(), g, &report)
+}
+
+// step applies the strategies in order until one of them changes the
+// grid, recording progress in report.
+func (s *Solver) step(ctx context.Context, g *Grid, report *SolveReport) (bool, error) {
+	report.Passes++
+	for _, strategy := range s.Strategies {
+		if (g.CountEmptyCells() == 0) {
+			break
+		}
+		err := ctx.Err()
+		if (err != nil) {
+			return false, err
+		}
+		progress, err := strategy.Apply(g)
+		if (err != nil) {
+			return false, err
+		}
+		s.notify(progress.Steps)
+		report.add(strategy.Name(), progress)
+		if (progress.Made()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Solve steps until the grid is full or no strategy makes progress. It
+// stops with a *ContradictionError as soon as the grid cannot be
+// solved. When the strategies are stuck, the error tells why: a
+// *NotUniqueError or a *TechniquesError, both wrapping ErrNotSolved.
+// In verbose mode, the grid is printed after each step.
+func (s *Solver) Solve(g *Grid) (SolveReport, error) {
+	return s.SolveContext(context.Background(), g)
+}
+
+// SolveContext is like Solve, but gives up as soon as ctx is done. The
+// grid is then left as far as the solver got, and ctx.Err() returned.
+// Grids repeating a value in a house are not solved, see Validate.
+func (s *Solver) SolveContext(ctx context.Context, g *Grid) (SolveReport, error) {
+	var report SolveReport
+	var start = time.Now()
+	err := g.Validate()
+	if (err == nil) {
+		err = s.solve(ctx, g, &report)
+	}
+	if (errors.Is(err, ErrNotSolved)) {
+		if (s.Backtrack) {
+			err = s.backtrack(ctx, g, &report)
+		} else {
+			err = s.diagnose(g)
+		}
+	}
+	report.Elapsed = time.Since(start)
+	report.EmptyCells = g.CountEmptyCells()
+	report.Solved = report.EmptyCells == 0
+	return report, err
+}
+
+func (s *Solver) solve(ctx context.Context, g *Grid, report *SolveReport) error {
+	for (g.CountEmptyCells() > 0) {
+		var contradiction = g.contradiction()
+		if (contradiction != nil) {
+			return contradiction
+		}
+		changed, err := s.step(ctx, g, report)
+		if (err != nil) {
+			return err
+		}
+		if (!changed) {
+			return ErrNotSolved
+		}
+		if (g.Verbose && g.CountEmptyCells() > 0) {
+			g.Render(os.Stdout, FormatHints)
+		}
+		for _, fn := range s.onPass {
+			fn(g)
+		}
+	}
+	return nil
+}
+
+// SolveContext solves g with the default strategies, giving up as soon
+// as ctx is done. See Solver.SolveContext.
+func SolveContext(ctx context.Context, g *Grid) (SolveReport, error) {
+	return NewSolver().SolveContext(ctx, g)
+}
+
+// Hardest returns the technique of the report coming last in the
+// strategies of the solver, which are ordered from the simplest, or ""
+// if no technique made progress. Backtracking is the hardest of all.
+func (s *Solver) Hardest(report SolveReport) string {
+	if (slices.Contains(report.Techniques, backtracking)) {
+		return backtracking
+	}
+	var hardest string = ""
+	for _, strategy := range s.Strategies {
+		for _, technique := range report.Techniques {
+			if (technique == strategy.Name()) {
+				hardest = technique
+			}
+		}
+	}
+	return hardest
+}
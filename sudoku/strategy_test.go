@@ -0,0 +1,78 @@
+package sudoku
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// patternGrid returns a grid whose empty cells can all hold any value,
+// but the given cells holding the given options.
+func patternGrid(t *testing.T, options map[Coord][]int) *Grid {
+	var marks [81]string
+	for i := range marks {
+		marks[i] = "123456789"
+	}
+	for cell, values := range options {
+		var mark = []byte(".........")
+		for _, value := range values {
+			mark[value-1] = byte('0' + value)
+		}
+		marks[cell.Row*9+cell.Col] = string(mark)
+	}
+	g, err := ParseSukaku(strings.Join(marks[:], ""))
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// valueGrid returns a grid where value can only go in the cells marked
+// x in picture, nine lines of nine cells, and the other values can go
+// anywhere.
+func valueGrid(t *testing.T, value int, picture string) *Grid {
+	var lines = strings.Fields(picture)
+	if (len(lines) != 9) {
+		t.Fatalf("got %d lines in the picture, want 9", len(lines))
+	}
+	var options = make(map[Coord][]int)
+	for row, line := range lines {
+		for col, mark := range line {
+			if (mark != 'x') {
+				options[Coord{row, col}] = allBut(value)
+			}
+		}
+	}
+	return patternGrid(t, options)
+}
+
+// allBut returns the values from 1 to 9 but the given ones.
+func allBut(values ...int) []int {
+	var others []int
+	for value := 1; value <= 9; value++ {
+		if (!slices.Contains(values, value)) {
+			others = append(others, value)
+		}
+	}
+	return others
+}
+
+// eliminations applies strategy to g and returns the options it
+// removed, sorted and written as r1c2-6, failing the test on any other
+// deduction.
+func eliminations(t *testing.T, strategy Strategy, g *Grid) string {
+	progress, err := strategy.Apply(g)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	var eliminated []string
+	for _, step := range progress.Steps {
+		if (step.Kind != Elimination || step.Technique != strategy.Name()) {
+			t.Errorf("unexpected step %+v", step)
+		}
+		eliminated = append(eliminated, fmt.Sprintf("%v-%d", Coord{step.Row, step.Col}, step.Value))
+	}
+	slices.Sort(eliminated)
+	return strings.Join(eliminated, " ")
+}
@@ -0,0 +1,36 @@
+package sudoku
+
+// NakedPair removes, from the other cells of a house, the two options
+// of two cells of the house which have exactly these two options: the
+// two values must go in these cells, in one order or the other.
+type NakedPair struct{}
+
+func (NakedPair) Name() string {
+	return "naked-pair"
+}
+
+func (n NakedPair) Apply(g *Grid) (Progress, error) {
+	var progress Progress
+	for _, house := range houses {
+		for i, first := range house.Cells {
+			var pair = g.options[first.Row][first.Col]
+			if (pair.count() != 2) {
+				continue
+			}
+			for _, second := range house.Cells[i+1:] {
+				if (pair != g.options[second.Row][second.Col]) {
+					continue
+				}
+				for _, cell := range house.Cells {
+					if (cell == first || cell == second) {
+						continue
+					}
+					for _, value := range pair.values() {
+						g.eliminate(&progress, n.Name(), cell, value, house.String(), "")
+					}
+				}
+			}
+		}
+	}
+	return progress, nil
+}
@@ -0,0 +1,42 @@
+package sudoku
+
+import (
+	"testing"
+)
+
+func TestNakedPair(t *testing.T) {
+	var tests = []struct {
+		name       string
+		options    map[Coord][]int
+		eliminated string
+	}{
+		{
+			"pair in a column",
+			map[Coord][]int{{1, 4}: {3, 8}, {6, 4}: {3, 8}, {0, 4}: {1, 3}, {2, 4}: {2, 8}},
+			"r1c5-3 r3c5-8 r4c5-3 r4c5-8 r5c5-3 r5c5-8 r6c5-3 r6c5-8 r8c5-3 r8c5-8 r9c5-3 r9c5-8",
+		},
+		{
+			"pair in a row and a square",
+			map[Coord][]int{{0, 0}: {1, 2}, {0, 2}: {1, 2}, {0, 1}: {1, 5}, {1, 0}: {2, 6}, {2, 2}: {7, 8}},
+			"r1c2-1 r1c4-1 r1c4-2 r1c5-1 r1c5-2 r1c6-1 r1c6-2 r1c7-1 r1c7-2 r1c8-1 r1c8-2 r1c9-1 r1c9-2 " +
+				"r2c1-2 r2c2-1 r2c2-2 r2c3-1 r2c3-2 r3c1-1 r3c1-2 r3c2-1 r3c2-2",
+		},
+		{
+			"cells of the same house with different pairs",
+			map[Coord][]int{{4, 0}: {1, 2}, {4, 8}: {1, 3}},
+			"",
+		},
+		{
+			"cells with the same pair in different houses",
+			map[Coord][]int{{0, 0}: {4, 5}, {4, 4}: {4, 5}},
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		var eliminated = eliminations(t, NakedPair{}, patternGrid(t, test.options))
+		if (eliminated != test.eliminated) {
+			t.Errorf("%s: eliminated %s, want %s", test.name, eliminated, test.eliminated)
+		}
+	}
+}
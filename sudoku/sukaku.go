@@ -0,0 +1,41 @@
+package sudoku
+
+import (
+	"fmt"
+)
+
+// ParseSukaku reads a pencil-mark puzzle (sukaku): instead of clues,
+// each cell gives the values it may hold. The 729 characters describe
+// the cells line by line, nine characters per cell, where the n-th
+// character is n if n is a candidate, or 0 or . if it is not. For
+// example, a first cell that can be 1, 4 or 9 starts with
+//   100400009
+// The options of the grid are the given candidates, there is no need
+// to list them.
+func ParseSukaku(str string) (*Grid, error) {
+	if (len(str) != 729) {
+		return nil, fmt.Errorf("not a valid sukaku, submit 729 values, got %d", len(str))
+	}
+
+	var g = NewGrid([9][9]int{})
+	for i := 0; i < 81; i++ {
+		var marks digitSet = 0
+		for n := 1; n <= 9; n++ {
+			var ch = str[i*9+n-1]
+			switch {
+			case ch == '0' || ch == '.':
+			case int(ch-'0') == n:
+				marks |= 1 << n
+			default:
+				return nil, fmt.Errorf("not a valid sukaku, expected %d, 0 or . at position %d, got %q", n, i*9+n, ch)
+			}
+		}
+		if (marks == 0) {
+			return nil, fmt.Errorf("not a valid sukaku, r%dc%d has no candidate", i/9+1, i%9+1)
+		}
+		g.marks[i/9][i%9] = marks
+		g.options[i/9][i%9] = marks
+	}
+	g.listed = true
+	return g, nil
+}
@@ -0,0 +1,52 @@
+package sudoku
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSukaku(t *testing.T) {
+	// r1c1 can hold 1 or 2, r1c2 only 9, the other cells anything
+	var sukaku = "12......." + "........9" + strings.Repeat("123456789", 79)
+	for _, text := range []string{sukaku, strings.ReplaceAll(sukaku, ".", "0")} {
+		g, err := ParseSukaku(text)
+		if (err != nil) {
+			t.Fatal(err)
+		}
+		if (!reflect.DeepEqual(g.Candidates(0, 0), []int{1, 2}) || !reflect.DeepEqual(g.Candidates(0, 1), []int{9}) || len(g.Candidates(8, 8)) != 9) {
+			t.Errorf("got candidates %v, %v and %v", g.Candidates(0, 0), g.Candidates(0, 1), g.Candidates(8, 8))
+		}
+
+		// listing the options again keeps to the marks
+		g.ListOptions()
+		if (!reflect.DeepEqual(g.Candidates(0, 0), []int{1, 2})) {
+			t.Errorf("listed %v in r1c1, want [1 2]", g.Candidates(0, 0))
+		}
+	}
+
+	var spaced = strings.Join(strings.SplitAfter(sukaku, "123456789"), " \n")
+	g, err := ParseGrid(strings.NewReader(spaced))
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	if (!reflect.DeepEqual(g.Candidates(0, 0), []int{1, 2})) {
+		t.Errorf("read %v in r1c1 from ParseGrid, want [1 2]", g.Candidates(0, 0))
+	}
+
+	var invalid = []struct {
+		name string
+		text string
+	}{
+		{"728 values", sukaku[1:]},
+		{"value out of place", "21......." + sukaku[9:]},
+		{"letter", "1x......." + sukaku[9:]},
+		{"cell without candidate", "........." + sukaku[9:]},
+	}
+	for _, test := range invalid {
+		_, err := ParseSukaku(test.text)
+		if (err == nil) {
+			t.Errorf("%s: got no error", test.name)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package sudoku
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// Sizes of the SVG drawing, in pixels.
+const (
+	svgCell   = 50 // side of a cell
+	svgMargin = 10 // blank space around the grid
+)
+
+// Colors of the SVG drawing.
+const (
+	svgGivenColor  = "#000000"
+	svgPlacedColor = "#1a5fb4"
+	svgMarkColor   = "#777777"
+	svgThinColor   = "#999999"
+)
+
+// RenderSVG writes an SVG image of the grid: thick lines around
+// squares, givens in bold, values placed by the solver in blue, and
+// the options of empty cells as small pencil marks. Render the grid
+// before ListOptions for a drawing without pencil marks.
+func (g *Grid) RenderSVG(w io.Writer) error {
+	var side = 9*svgCell + 2*svgMargin
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n", side, side, side, side)
+	fmt.Fprintf(&buf, "<rect width=\"%d\" height=\"%d\" fill=\"#ffffff\"/>\n", side, side)
+	fmt.Fprintln(&buf, "<g font-family=\"Helvetica, Arial, sans-serif\" text-anchor=\"middle\" dominant-baseline=\"central\">")
+
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			var x = svgMargin + col*svgCell
+			var y = svgMargin + row*svgCell
+			if (g.cells[row][col] != 0) {
+				var weight, fill = "normal", svgPlacedColor
+				if (g.givens[row][col]) {
+					weight, fill = "bold", svgGivenColor
+				}
+				fmt.Fprintf(&buf, "<text x=\"%d\" y=\"%d\" font-size=\"32\" font-weight=\"%s\" fill=\"%s\">%d</text>\n",
+					x+svgCell/2, y+svgCell/2, weight, fill, g.cells[row][col])
+				continue
+			}
+			// pencil marks sit in a 3x3 layout, 1 at the top left
+			for _, value := range g.options[row][col].values() {
+				var markX = x + (value-1)%3*svgCell/3 + svgCell/6
+				var markY = y + (value-1)/3*svgCell/3 + svgCell/6
+				fmt.Fprintf(&buf, "<text x=\"%d\" y=\"%d\" font-size=\"12\" fill=\"%s\">%d</text>\n",
+					markX, markY, svgMarkColor, value)
+			}
+		}
+	}
+	fmt.Fprintln(&buf, "</g>")
+
+	// thin lines first, so that the thick ones are drawn over them
+	for _, thick := range []bool{false, true} {
+		for i := 0; i <= 9; i++ {
+			if ((i%3 == 0) != thick) {
+				continue
+			}
+			var color, width = svgThinColor, 1
+			if (thick) {
+				color, width = svgGivenColor, 3
+			}
+			var pos = svgMargin + i*svgCell
+			fmt.Fprintf(&buf, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\" stroke=\"%s\" stroke-width=\"%d\" stroke-linecap=\"square\"/>\n",
+				pos, svgMargin, pos, svgMargin+9*svgCell, color, width)
+			fmt.Fprintf(&buf, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\" stroke=\"%s\" stroke-width=\"%d\" stroke-linecap=\"square\"/>\n",
+				svgMargin, pos, svgMargin+9*svgCell, pos, color, width)
+		}
+	}
+	fmt.Fprintln(&buf, "</svg>")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
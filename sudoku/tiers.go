@@ -0,0 +1,92 @@
+package sudoku
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// Tier is a group of strategies of a kind, named so that they can be
+// allowed together.
+type Tier struct {
+	Name       string
+	Strategies []string // names of the strategies, from the cheapest
+	OptIn      bool     // left out of DefaultStrategies
+}
+
+// Tiers are the tiers of the registered strategies, from the one whose
+// cheapest strategy is the cheapest. Jellyfish, slow and rarely needed,
+// has a tier of its own so that allowing fish does not enable it.
+var Tiers = []Tier{
+	{"singles", []string{"naked-single", "hidden-single"}, false},
+	{"intersections", []string{"pointing", "claiming"}, false},
+	{"pairs", []string{"naked-pair"}, false},
+	{"fish", []string{"x-wing", "swordfish"}, false},
+	{"single-digit", []string{"skyscraper", "two-string-kite"}, false},
+	{"wings", []string{"xyz-wing"}, false},
+	{"coloring", []string{"remote-pairs", "3d-medusa"}, false},
+	{"uniqueness", []string{"unique-rectangle"}, true},
+	{"big-fish", []string{"jellyfish"}, true},
+	{"chains", []string{"nishio"}, false},
+	{"search", []string{"dlx"}, true},
+}
+
+// TierNames returns the names of the Tiers, from the cheapest.
+func TierNames() []string {
+	var names []string
+	for _, tier := range Tiers {
+		names = append(names, tier.Name)
+	}
+	return names
+}
+
+// cost ranks a strategy by the weight Rate gives it, so that the solver
+// applies the techniques in the order of their difficulty. Strategies
+// without a weight, such as dlx, come last.
+func cost(name string) float64 {
+	return techniqueWeight(name)
+}
+
+// SortStrategies sorts strategies from the cheapest, the order of
+// DefaultStrategies and StrategiesByTechnique.
+func SortStrategies(strategies []Strategy) {
+	slices.SortStableFunc(strategies, func(a Strategy, b Strategy) int {
+		return cmp.Compare(cost(a.Name()), cost(b.Name()))
+	})
+}
+
+// StrategiesByTechnique returns the strategies of the given tiers and
+// strategy names, from the cheapest, whatever their order in names:
+// as the solver starts each pass over with its first strategy, the
+// cheapest one making progress is always the one applied.
+func StrategiesByTechnique(names []string) ([]Strategy, error) {
+	var selected []string
+	var add = func(name string) {
+		if (!slices.Contains(selected, name)) {
+			selected = append(selected, name)
+		}
+	}
+	for _, name := range names {
+		var i = slices.IndexFunc(Tiers, func(tier Tier) bool {
+			return tier.Name == name
+		})
+		if (i >= 0) {
+			for _, strategy := range Tiers[i].Strategies {
+				add(strategy)
+			}
+			continue
+		}
+		_, ok := Lookup(name)
+		if (!ok) {
+			return nil, fmt.Errorf("unknown technique %q", name)
+		}
+		add(name)
+	}
+
+	strategies, err := StrategiesByName(selected)
+	if (err != nil) {
+		return nil, err
+	}
+	SortStrategies(strategies)
+	return strategies, nil
+}
@@ -0,0 +1,106 @@
+package sudoku
+
+import (
+	"fmt"
+)
+
+// The transformations below return a new grid, equivalent to g: a
+// valid puzzle stays valid, with the same number of solutions. Options
+// move along with their cells.
+
+// transform returns a grid whose cell at (row, col) holds what g holds
+// at from(row, col).
+func (g *Grid) transform(from func(row int, col int) Coord) *Grid {
+	var t = &Grid{Verbose: g.Verbose, listed: g.listed}
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			var src = from(row, col)
+			t.cells[row][col] = g.cells[src.Row][src.Col]
+			t.options[row][col] = g.options[src.Row][src.Col]
+			t.marks[row][col] = g.marks[src.Row][src.Col]
+			t.givens[row][col] = g.givens[src.Row][src.Col]
+		}
+	}
+	return t
+}
+
+// Rotate90 returns the grid rotated a quarter turn clockwise.
+func (g *Grid) Rotate90() *Grid {
+	return g.transform(func(row int, col int) Coord {
+		return Coord{8 - col, row}
+	})
+}
+
+// Mirror returns the grid reflected left to right.
+func (g *Grid) Mirror() *Grid {
+	return g.transform(func(row int, col int) Coord {
+		return Coord{row, 8 - col}
+	})
+}
+
+// Transpose returns the grid reflected along its main diagonal, rows
+// becoming columns.
+func (g *Grid) Transpose() *Grid {
+	return g.transform(func(row int, col int) Coord {
+		return Coord{col, row}
+	})
+}
+
+// swapIndex returns i with the groups of three a and b swapped.
+func swapIndex(i int, a int, b int) int {
+	switch i / 3 {
+	case a:
+		return b*3 + i%3
+	case b:
+		return a*3 + i%3
+	}
+	return i
+}
+
+// SwapBands returns the grid with two bands swapped. Bands are the
+// three groups of three rows, numbered from 0 to 2.
+func (g *Grid) SwapBands(a int, b int) *Grid {
+	if (a < 0 || a > 2 || b < 0 || b > 2) {
+		panic(fmt.Sprintf("sudoku: bands %d and %d out of range", a, b))
+	}
+	return g.transform(func(row int, col int) Coord {
+		return Coord{swapIndex(row, a, b), col}
+	})
+}
+
+// SwapStacks returns the grid with two stacks swapped. Stacks are the
+// three groups of three columns, numbered from 0 to 2.
+func (g *Grid) SwapStacks(a int, b int) *Grid {
+	if (a < 0 || a > 2 || b < 0 || b > 2) {
+		panic(fmt.Sprintf("sudoku: stacks %d and %d out of range", a, b))
+	}
+	return g.transform(func(row int, col int) Coord {
+		return Coord{row, swapIndex(col, a, b)}
+	})
+}
+
+// PermuteDigits returns the grid with each value v replaced by
+// perm[v-1]. perm must hold each value from 1 to 9 once.
+func (g *Grid) PermuteDigits(perm [9]int) (*Grid, error) {
+	var seen [10]bool
+	for _, value := range perm {
+		if (value < 1 || value > 9 || seen[value]) {
+			return nil, fmt.Errorf("not a permutation of 1 to 9: %v", perm)
+		}
+		seen[value] = true
+	}
+
+	var t = g.transform(func(row int, col int) Coord {
+		return Coord{row, col}
+	})
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if (t.cells[row][col] != 0) {
+				t.cells[row][col] = perm[t.cells[row][col]-1]
+			}
+			t.options[row][col] = g.options[row][col].permute(perm)
+			t.marks[row][col] = g.marks[row][col].permute(perm)
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,160 @@
+package sudoku
+
+import (
+	"fmt"
+	"slices"
+)
+
+// UniqueRectangle removes options which would leave a deadly pattern:
+// four cells at the corners of a rectangle over two squares, all with
+// options a and b. If these were their only options, a and b could be
+// swapped in the solution, so one of the corners must hold another
+// value (types 1 to 4). It relies on the puzzle having a single
+// solution, so it is not one of the DefaultStrategies: select it by
+// name, or with --assume-unique on the command line.
+type UniqueRectangle struct{}
+
+func (UniqueRectangle) Name() string {
+	return "unique-rectangle"
+}
+
+func (u UniqueRectangle) Apply(g *Grid) (Progress, error) {
+	var progress Progress
+	for r1 := 0; r1 < 9; r1++ {
+		for r2 := r1 + 1; r2 < 9; r2++ {
+			for c1 := 0; c1 < 9; c1++ {
+				for c2 := c1 + 1; c2 < 9; c2++ {
+					// two corners in the same square, the other two in another
+					if ((r1/3 == r2/3) == (c1/3 == c2/3)) {
+						continue
+					}
+					var corners = [4]Coord{{r1, c1}, {r1, c2}, {r2, c1}, {r2, c2}}
+					for _, pair := range g.rectanglePairs(corners) {
+						g.applyRectangle(&progress, u.Name(), corners, pair)
+					}
+				}
+			}
+		}
+	}
+	return progress, nil
+}
+
+// rectanglePairs returns the pairs of values which are options of the
+// four corners, all empty.
+func (g *Grid) rectanglePairs(corners [4]Coord) [][2]int {
+	var common []int
+	for value := 1; value <= 9; value++ {
+		var everywhere bool = true
+		for _, corner := range corners {
+			everywhere = everywhere && g.HasCandidate(corner.Row, corner.Col, value)
+		}
+		if (everywhere) {
+			common = append(common, value)
+		}
+	}
+	var pairs [][2]int
+	for i, a := range common {
+		for _, b := range common[i+1:] {
+			pairs = append(pairs, [2]int{a, b})
+		}
+	}
+	return pairs
+}
+
+// applyRectangle looks for the unique rectangle types on the corners
+// with options pair.
+func (g *Grid) applyRectangle(progress *Progress, technique string, corners [4]Coord, pair [2]int) {
+	var floor, roof []Coord // corners with the pair only, and with more options
+	for _, corner := range corners {
+		if (g.options[corner.Row][corner.Col].count() == 2) {
+			floor = append(floor, corner)
+		} else {
+			roof = append(roof, corner)
+		}
+	}
+	var name = fmt.Sprintf("%v, %v, %v and %v could swap %d and %d", corners[0], corners[1], corners[2], corners[3], pair[0], pair[1])
+
+	if (len(floor) == 3) {
+		for _, value := range pair {
+			g.eliminate(progress, technique, roof[0], value, "", "type 1: "+name)
+		}
+		return
+	}
+	// the roof cells share a row or a column, or lie diagonally
+	if (len(floor) != 2 || (roof[0].Row != roof[1].Row && roof[0].Col != roof[1].Col)) {
+		return
+	}
+
+	// type 2: the roof cells have the same single extra option
+	var pairSet = digitSetOf(pair[:])
+	var extra0 = g.options[roof[0].Row][roof[0].Col] &^ pairSet
+	var extra1 = g.options[roof[1].Row][roof[1].Col] &^ pairSet
+	if (extra0.count() == 1 && extra0 == extra1) {
+		for _, cell := range peers[roof[0].Row][roof[0].Col] {
+			if (cell != roof[1] && cell.Sees(roof[1])) {
+				g.eliminate(progress, technique, cell, extra0.first(), "", fmt.Sprintf("type 2: one of %v and %v holds %d, or %s", roof[0], roof[1], extra0.first(), name))
+			}
+		}
+	}
+
+	for _, house := range houses {
+		if (!house.Contains(roof[0]) || !house.Contains(roof[1])) {
+			continue
+		}
+
+		// type 3: the extra options of the roof form a naked subset
+		// with other cells of the house
+		var extras = extra0 | extra1
+		if (extras.count() >= 2) {
+			g.applyRectangleSubset(progress, technique, house, roof, extras, "type 3: "+name)
+		}
+
+		// type 4: one value of the pair has no other place in the
+		// house than the roof, so the roof cannot hold the other one
+		for i, value := range pair {
+			var places = g.placesOf(house, value)
+			if (len(places) == 2 && places[0] == roof[0] && places[1] == roof[1]) {
+				var other = pair[1-i]
+				var reason = fmt.Sprintf("type 4: %v or %v holds %d in %v, or %s", roof[0], roof[1], value, house, name)
+				g.eliminate(progress, technique, roof[0], other, house.String(), reason)
+				g.eliminate(progress, technique, roof[1], other, house.String(), reason)
+			}
+		}
+	}
+}
+
+// applyRectangleSubset looks for cells of the house which, with the
+// roof seen as a single cell whose options are extras, hold as many
+// values as there are cells, and removes these values from the rest of
+// the house.
+func (g *Grid) applyRectangleSubset(progress *Progress, technique string, house House, roof []Coord, extras digitSet, reason string) {
+	var others []Coord
+	for _, cell := range house.Cells {
+		if (!slices.Contains(roof, cell) && g.cells[cell.Row][cell.Col] == 0 && g.options[cell.Row][cell.Col] != 0) {
+			others = append(others, cell)
+		}
+	}
+
+	var search func(start int, subset []Coord, values digitSet)
+	search = func(start int, subset []Coord, values digitSet) {
+		if (values.count() > 4) {
+			return
+		}
+		if (len(subset) > 0 && values.count() == len(subset)+1) {
+			for _, cell := range others {
+				if (slices.Contains(subset, cell)) {
+					continue
+				}
+				for _, value := range values.values() {
+					g.eliminate(progress, technique, cell, value, house.String(), reason)
+				}
+			}
+			return
+		}
+		for i := start; i < len(others); i++ {
+			var cell = others[i]
+			search(i+1, append(subset, cell), values|g.options[cell.Row][cell.Col])
+		}
+	}
+	search(0, nil, extras)
+}
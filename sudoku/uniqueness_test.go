@@ -0,0 +1,50 @@
+package sudoku
+
+import (
+	"testing"
+)
+
+func TestUniqueRectangle(t *testing.T) {
+	var tests = []struct {
+		name       string
+		options    map[Coord][]int
+		eliminated string
+	}{
+		{
+			"type 1, a single corner with extra options",
+			map[Coord][]int{{0, 0}: {1, 2}, {0, 3}: {1, 2}, {1, 0}: {1, 2}, {1, 3}: {1, 2, 7}},
+			"r2c4-1 r2c4-2",
+		},
+		{
+			"type 2, the same extra option in two corners",
+			map[Coord][]int{{0, 0}: {1, 2}, {0, 3}: {1, 2}, {1, 0}: {1, 2, 5}, {1, 3}: {1, 2, 5}},
+			"r2c2-5 r2c3-5 r2c5-5 r2c6-5 r2c7-5 r2c8-5 r2c9-5",
+		},
+		{
+			"type 3, extra options making a naked pair with another cell",
+			map[Coord][]int{{0, 0}: {1, 2}, {0, 3}: {1, 2}, {1, 0}: {1, 2, 3}, {1, 3}: {1, 2, 4}, {1, 6}: {3, 4}},
+			"r2c2-3 r2c2-4 r2c3-3 r2c3-4 r2c5-3 r2c5-4 r2c6-3 r2c6-4 r2c8-3 r2c8-4 r2c9-3 r2c9-4",
+		},
+		{
+			"type 4, a value of the pair only in the roof of its square",
+			map[Coord][]int{
+				{0, 0}: {1, 2}, {0, 1}: {1, 2}, {3, 0}: {1, 2, 6}, {3, 1}: {1, 2, 7},
+				{3, 2}: allBut(1), {4, 0}: allBut(1), {4, 1}: allBut(1), {4, 2}: allBut(1),
+				{5, 0}: allBut(1), {5, 1}: allBut(1), {5, 2}: allBut(1),
+			},
+			"r4c1-2 r4c2-2",
+		},
+		{
+			"corners in four squares",
+			map[Coord][]int{{0, 0}: {1, 2}, {0, 3}: {1, 2}, {4, 0}: {1, 2}, {4, 3}: {1, 2, 7}},
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		var eliminated = eliminations(t, UniqueRectangle{}, patternGrid(t, test.options))
+		if (eliminated != test.eliminated) {
+			t.Errorf("%s: eliminated %s, want %s", test.name, eliminated, test.eliminated)
+		}
+	}
+}
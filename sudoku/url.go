@@ -0,0 +1,54 @@
+package sudoku
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// urlParams lists the query parameters holding the puzzle in the share
+// links of known sites.
+var urlParams = map[string]string{
+	"sudokuwiki.org":     "bd",
+	"sudokuexchange.com": "s",
+}
+
+// ParseURL extracts the puzzle from a share link, such as
+//   https://www.sudokuwiki.org/sudoku.htm?bd=006000300435...
+//   https://sudokuexchange.com/play/?s=006000300435...
+// Links of other sites are searched for a query value or path segment
+// of 81 values, see ParseCells.
+func ParseURL(raw string) (*Grid, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if (err != nil) {
+		return nil, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("not a puzzle link: %q", raw)
+	}
+
+	var host = strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	var query = u.Query()
+	param, ok := urlParams[host]
+	if (ok) {
+		var value = query.Get(param)
+		if (value == "") {
+			return nil, fmt.Errorf("%s link without %s parameter", host, param)
+		}
+		return Parse(value)
+	}
+
+	var found []string
+	for _, values := range query {
+		found = append(found, values...)
+	}
+	found = append(found, strings.Split(u.Path, "/")...)
+	found = append(found, u.Fragment)
+	for _, value := range found {
+		cells, err := ParseCells(strings.TrimSpace(value))
+		if (err == nil) {
+			return NewGrid(cells), nil
+		}
+	}
+	return nil, fmt.Errorf("no puzzle found in link %q", raw)
+}
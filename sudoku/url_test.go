@@ -0,0 +1,44 @@
+package sudoku
+
+import (
+	"testing"
+)
+
+const urlPuzzle = "004209000900000020000367000500000710100080903600903000070000501000001040800000000"
+
+func TestParseURL(t *testing.T) {
+	var links = []string{
+		"https://www.sudokuwiki.org/sudoku.htm?bd=" + urlPuzzle,
+		"https://sudokuexchange.com/play/?s=" + urlPuzzle,
+		"http://example.com/puzzles/" + urlPuzzle + "/play",
+		"https://example.com/play?level=3&grid=" + urlPuzzle,
+		"https://example.com/play#" + urlPuzzle,
+	}
+	want, err := ParseCells(urlPuzzle)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+	for _, link := range links {
+		g, err := ParseURL(link)
+		if (err != nil) {
+			t.Errorf("%s: %v", link, err)
+			continue
+		}
+		if (g.Cells() != want) {
+			t.Errorf("%s: parsed %s", link, CellsString(g.Cells(), '0'))
+		}
+	}
+
+	var invalid = []string{
+		urlPuzzle,
+		"ftp://example.com/" + urlPuzzle,
+		"https://www.sudokuwiki.org/sudoku.htm?grid=" + urlPuzzle,
+		"https://example.com/play?level=3",
+	}
+	for _, link := range invalid {
+		_, err := ParseURL(link)
+		if (err == nil) {
+			t.Errorf("%s: got no error", link)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidClues is wrapped by the errors of Validate.
+var ErrInvalidClues = errors.New("invalid clues")
+
+// ClueError tells that filled cells repeat a value in a house.
+type ClueError struct {
+	House string
+	Value int
+	Cells []Coord // cells of the house holding Value
+}
+
+func (e *ClueError) Error() string {
+	var cells string
+	for i, cell := range e.Cells {
+		if (i > 0) {
+			cells += ", "
+		}
+		cells += cell.String()
+	}
+	return fmt.Sprintf("%v, %d is repeated in %s (%s)", ErrInvalidClues, e.Value, e.House, cells)
+}
+
+func (e *ClueError) Unwrap() error {
+	return ErrInvalidClues
+}
+
+// Validate checks that no value is repeated in a row, column or square
+// of the grid. It returns a *ClueError per repeated value, joined with
+// errors.Join, or nil. Such grids cannot be solved, and their options
+// make no sense.
+func (g *Grid) Validate() error {
+	var errs []error
+	for _, house := range houses {
+		var cells [10][]Coord
+		for _, cell := range house.Cells {
+			var value int = g.cells[cell.Row][cell.Col]
+			if (value != 0) {
+				cells[value] = append(cells[value], cell)
+			}
+		}
+		for value := 1; value <= 9; value++ {
+			if (len(cells[value]) > 1) {
+				errs = append(errs, &ClueError{house.String(), value, cells[value]})
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
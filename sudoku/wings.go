@@ -0,0 +1,49 @@
+package sudoku
+
+// XYZWing removes a value from the cells seeing three cells: a pivot
+// with three options xyz, and two pincers it sees with options xz and
+// yz. Whatever the pivot holds, one of the three cells holds z.
+type XYZWing struct{}
+
+func (XYZWing) Name() string {
+	return "xyz-wing"
+}
+
+func (x XYZWing) Apply(g *Grid) (Progress, error) {
+	var progress Progress
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			var options = g.options[row][col]
+			if (g.cells[row][col] != 0 || options.count() != 3) {
+				continue
+			}
+
+			// pincers have two options of the pivot
+			var pincers []Coord
+			for _, peer := range peers[row][col] {
+				var peerOptions = g.options[peer.Row][peer.Col]
+				if (peerOptions.count() == 2 && peerOptions&^options == 0) {
+					pincers = append(pincers, peer)
+				}
+			}
+
+			for i, first := range pincers {
+				for _, second := range pincers[i+1:] {
+					var a, b = g.options[first.Row][first.Col], g.options[second.Row][second.Col]
+					if (a == b) {
+						continue
+					}
+					// the common value of both pincers
+					var z int = (a & b).first()
+					for _, cell := range peers[row][col] {
+						if (cell == first || cell == second || !cell.Sees(first) || !cell.Sees(second)) {
+							continue
+						}
+						g.eliminate(&progress, x.Name(), cell, z, "", "")
+					}
+				}
+			}
+		}
+	}
+	return progress, nil
+}
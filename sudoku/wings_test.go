@@ -0,0 +1,59 @@
+package sudoku
+
+import (
+	"testing"
+)
+
+// xyzWingPosition is the pencil marks of a puzzle at the point where the
+// simpler techniques are stuck and XYZ-Wing is the next step:
+//   ..8..17..19.75..2........9..25...........2.1.43.....5...9.7.8....698..4.......1..
+// The pivot r1c1 {356} sees the pincers r3c1 {56} and r1c8 {36}, so 6
+// leaves r1c2, the only cell holding it which sees all three.
+const xyzWingPosition = "..3.56......456..........8..2...............91..............7....3..6.....3456...1................9..34...........7......5.........6.....34......2..............8.....56.........7...2.........34.......34............8.....56...........91.............6..9.2...........5......34.....1..............7.9..34.6..9.......8...34.67.......6.89.....6.8.......7......5......34......2.........34....91..........34.6......4.......3......1...............8......6.........7.9.2......9....5.....2....7...2..........45............91..............7....345...........8...3..6.....3.56.........7..1.............6...........9.......8...3.5.....23.5.......4......23.5......3.5..8....45..8...34..........6....2..........45....1..............7..........9"
+
+func TestXYZWing(t *testing.T) {
+	sukaku, err := ParseSukaku(xyzWingPosition)
+	if (err != nil) {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name       string
+		grid       *Grid
+		eliminated string
+	}{
+		{"puzzle position", sukaku, "r1c2-6"},
+		{
+			"pincers in the row and the square of the pivot",
+			patternGrid(t, map[Coord][]int{{0, 0}: {1, 2, 3}, {0, 4}: {1, 3}, {1, 1}: {2, 3}}),
+			"r1c2-3 r1c3-3",
+		},
+		{
+			"pincers in the column and the square of the pivot",
+			patternGrid(t, map[Coord][]int{{4, 4}: {4, 7, 9}, {4, 3}: {4, 7}, {8, 4}: {7, 9}}),
+			"r4c5-7 r6c5-7",
+		},
+		{
+			"pincers in the row and the column of the pivot, no cell sees all three",
+			patternGrid(t, map[Coord][]int{{0, 0}: {1, 2, 3}, {0, 4}: {1, 3}, {4, 0}: {2, 3}}),
+			"",
+		},
+		{
+			"pivot not seeing a pincer",
+			patternGrid(t, map[Coord][]int{{0, 0}: {1, 2, 3}, {0, 4}: {1, 3}, {4, 4}: {2, 3}}),
+			"",
+		},
+		{
+			"pincers with the same options",
+			patternGrid(t, map[Coord][]int{{0, 0}: {1, 2, 3}, {0, 4}: {1, 3}, {1, 1}: {1, 3}}),
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		var eliminated = eliminations(t, XYZWing{}, test.grid)
+		if (eliminated != test.eliminated) {
+			t.Errorf("%s: eliminated %s, want %s", test.name, eliminated, test.eliminated)
+		}
+	}
+}